@@ -1,8 +1,14 @@
 package app
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"sync"
+	"time"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 )
 
 // Action represents a custom event that can be propagated across the app. It
@@ -16,6 +22,92 @@ type Action struct {
 
 	// Tags that provide some context to the action.
 	Tags Tags
+
+	replier *actionReplier
+}
+
+// DecodeValue stores the action value into the given receiver. Values of
+// actions handled on the server with a ServerActionHandler are decoded from
+// JSON. It returns an error when the receiver is not a pointer or when its
+// pointed value has a different type than the action value.
+func (a Action) DecodeValue(recv any) error {
+	if v, ok := a.Value.(json.RawMessage); ok {
+		return storeValue(recv, jsonValue(v))
+	}
+	return storeValue(recv, a.Value)
+}
+
+// Reply replies the given value to the action when it has been created with
+// Context.Request. Only the first reply is taken into account.
+func (a Action) Reply(v any) {
+	if a.replier != nil {
+		a.replier.reply(v, nil)
+	}
+}
+
+// ReplyError replies the given error to the action when it has been created
+// with Context.Request. Only the first reply is taken into account.
+func (a Action) ReplyError(err error) {
+	if a.replier != nil {
+		a.replier.reply(nil, err)
+	}
+}
+
+// actionReplier calls a function with the first reply to an action request.
+// An error is replied when the request context is done before a reply is
+// received.
+type actionReplier struct {
+	once    sync.Once
+	done    chan struct{}
+	onReply func(v any, err error)
+}
+
+func newActionReplier(ctx context.Context, actionName string, onReply func(v any, err error)) *actionReplier {
+	r := &actionReplier{
+		done:    make(chan struct{}),
+		onReply: onReply,
+	}
+
+	go func() {
+		select {
+		case <-r.done:
+
+		case <-ctx.Done():
+			r.reply(nil, errors.New("requesting action failed").
+				WithTag("action", actionName).
+				Wrap(ctx.Err()))
+		}
+	}()
+	return r
+}
+
+func (r *actionReplier) reply(v any, err error) {
+	r.once.Do(func() {
+		close(r.done)
+		r.onReply(v, err)
+	})
+}
+
+// Request creates an action with a value and optional tags, and calls the
+// given function on the UI goroutine with the value replied by the action
+// handler, stored into a value of type T. See Context.Request for more
+// details. Eg:
+//
+//	app.Request(ctx, "form/is-dirty", nil, time.Second, func(ctx app.Context, isDirty bool, err error) {
+//	    if err != nil {
+//	        app.Log(err)
+//	        return
+//	    }
+//	    ...
+//	})
+func Request[T any](ctx Context, name string, v any, timeout time.Duration, onReply func(ctx Context, reply T, err error), tags ...Tagger) {
+	ctx.Request(name, v, timeout, func(ctx Context, reply any, err error) {
+		var r T
+		if err == nil {
+			err = storeValue(&r, reply)
+		}
+		onReply(ctx, r, err)
+	}, tags...)
 }
 
 // ActionHandler represents a handler that is executed when an action is created
@@ -24,11 +116,103 @@ type ActionHandler func(Context, Action)
 
 // Handle registers the handler for the given action name. When an action
 // occurs, the handler is executed on its own goroutine.
+//
+// The handler is registered on the default router.
 func Handle(actionName string, h ActionHandler) {
-	actionHandlers[actionName] = h
+	defaultRouter.Handle(actionName, h)
+}
+
+// ActionMiddleware is a function that is executed when an action is posted,
+// before its handlers are executed.
+//
+// Middlewares receive every action. They let the action through by doing
+// nothing, and can add tags with ActionRequest.Tag, reject the action with
+// ActionRequest.Reject, or send it to the handlers of another action with
+// ActionRequest.Reroute. Middlewares are executed on their own goroutine.
+type ActionMiddleware func(ctx Context, r *ActionRequest)
+
+// UseActionMiddleware registers the given action middlewares. Middlewares are
+// executed in registration order until one rejects the action. Eg:
+//
+//	app.UseActionMiddleware(func(ctx app.Context, r *app.ActionRequest) {
+//	    app.Logf("action %s posted with tags %v", r.Action.Name, r.Action.Tags)
+//	})
+//
+// The middlewares are registered on the default router.
+func UseActionMiddleware(m ...ActionMiddleware) {
+	defaultRouter.UseActionMiddleware(m...)
+}
+
+// ActionRequest describes a posted action that is processed by action
+// middlewares.
+type ActionRequest struct {
+	// The posted action. Its value can be modified by middlewares.
+	Action Action
+
+	isRejected bool
+}
+
+// Tag sets a tag with the given name and value on the action. The value is
+// converted to a string.
+func (r *ActionRequest) Tag(name string, v any) {
+	if r.Action.Tags == nil {
+		r.Action.Tags = make(Tags)
+	}
+	r.Action.Tags.Set(name, v)
+}
+
+// Reroute sends the action to the handlers of the action with the given name.
+// The remaining middlewares receive the rerouted action.
+func (r *ActionRequest) Reroute(actionName string) {
+	r.Action.Name = actionName
+}
+
+// Reject prevents the action from being handled. The remaining middlewares are
+// not executed.
+func (r *ActionRequest) Reject() {
+	r.isRejected = true
 }
 
-var actionHandlers = make(map[string]ActionHandler)
+// ServerActionHandler represents a handler that is executed on the server when
+// an action is called from the client with Context.CallServer. The action
+// value is decoded with Action.DecodeValue.
+//
+// The returned value is encoded in JSON and sent back to the client. A
+// returned error is logged on the server, and the client gets a generic error
+// that does not contain its message.
+type ServerActionHandler func(r *http.Request, a Action) (any, error)
+
+// HandleServer registers the server handler for the given action name. The
+// handler is executed on the server when the action is called from the client
+// with Context.CallServer or CallServer, with the HTTP request of the call.
+// The request context is canceled when the client cancels the call.
+//
+// The handler is registered on the default router.
+func HandleServer(actionName string, h ServerActionHandler) {
+	defaultRouter.HandleServer(actionName, h)
+}
+
+// CallServer calls the server handler of the given action with the given value
+// and optional tags, and calls the given function on the UI goroutine with
+// the value returned by the handler, stored into a value of type T. See
+// Context.CallServer for more details. Eg:
+//
+//	app.CallServer(ctx, "user/save", u, 5*time.Second, func(ctx app.Context, id string, err error) {
+//	    if err != nil {
+//	        app.Log(err)
+//	        return
+//	    }
+//	    ...
+//	})
+func CallServer[T any](ctx Context, name string, v any, timeout time.Duration, onReply func(ctx Context, reply T, err error), tags ...Tagger) {
+	ctx.CallServer(name, v, timeout, func(ctx Context, reply json.RawMessage, err error) {
+		var r T
+		if err == nil && len(reply) != 0 {
+			err = storeValue(&r, jsonValue(reply))
+		}
+		onReply(ctx, r, err)
+	}, tags...)
+}
 
 type actionHandler struct {
 	async    bool
@@ -37,36 +221,97 @@ type actionHandler struct {
 }
 
 type actionManager struct {
-	once     sync.Once
-	mutex    sync.Mutex
-	handlers map[string]map[string]actionHandler
+	once        sync.Once
+	mutex       sync.Mutex
+	handlers    map[string]map[string]actionHandler
+	middlewares []ActionMiddleware
 }
 
 func (m *actionManager) init() {
 	m.handlers = make(map[string]map[string]actionHandler)
 }
 
+func (m *actionManager) use(middlewares ...ActionMiddleware) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.middlewares = append(m.middlewares, middlewares...)
+}
+
+// intercept executes the middlewares with the given action. It returns the
+// action modified by the middlewares and reports whether it is to be handled.
+func (m *actionManager) intercept(ctx Context, a Action) (Action, bool) {
+	m.mutex.Lock()
+	middlewares := m.middlewares
+	m.mutex.Unlock()
+
+	r := ActionRequest{Action: a}
+	for _, middleware := range middlewares {
+		middleware(ctx, &r)
+		if r.isRejected {
+			return r.Action, false
+		}
+	}
+	return r.Action, true
+}
+
 func (m *actionManager) post(a Action) {
 	m.once.Do(m.init)
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	handlers := m.handlers[a.Name]
+	for _, h := range m.mountedHandlers(a.Name) {
+		h.execute(a)
+	}
+}
+
+// request executes the handler of the given action. It returns an error when
+// the action has no handler or more than one.
+func (m *actionManager) request(a Action) error {
+	m.once.Do(m.init)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	handlers := m.mountedHandlers(a.Name)
+	switch len(handlers) {
+	case 1:
+		handlers[0].execute(a)
+		return nil
+
+	case 0:
+		return errors.New("requesting action failed").
+			WithTag("action", a.Name).
+			WithTag("reason", "action has no handler")
+
+	default:
+		return errors.New("requesting action failed").
+			WithTag("action", a.Name).
+			WithTag("reason", "action has multiple handlers").
+			WithTag("handlers", len(handlers))
+	}
+}
+
+func (m *actionManager) mountedHandlers(actionName string) []actionHandler {
+	handlers := m.handlers[actionName]
+	mounted := make([]actionHandler, 0, len(handlers))
 	for key, h := range handlers {
-		source := h.source
-		if !source.Mounted() {
+		if !h.source.Mounted() {
 			delete(handlers, key)
 			continue
 		}
+		mounted = append(mounted, h)
+	}
+	return mounted
+}
 
-		ctx := makeContext(source)
-		function := h.function
+func (h actionHandler) execute(a Action) {
+	ctx := makeContext(h.source)
+	function := h.function
 
-		if h.async {
-			ctx.Async(func() { function(ctx, a) })
-		} else {
-			ctx.Dispatch(func(ctx Context) { function(ctx, a) })
-		}
+	if h.async {
+		ctx.Async(func() { function(ctx, a) })
+	} else {
+		ctx.Dispatch(func(ctx Context) { function(ctx, a) })
 	}
 }
 
@@ -1,14 +1,29 @@
 package app
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
 func TestHandle(t *testing.T) {
 	Handle("/test", func(Context, Action) {})
-	require.Len(t, actionHandlers, 1)
+	require.Len(t, defaultRouter.actionHandlers, 1)
+}
+
+func TestRouterHandle(t *testing.T) {
+	r := NewRouter()
+	r.Handle("/router-test", func(Context, Action) {})
+	require.Len(t, r.getActionHandlers(), 1)
+	require.NotContains(t, defaultRouter.getActionHandlers(), "/router-test")
+
+	e := engine{Router: r}
+	e.init()
+	defer e.Close()
+	require.Contains(t, e.ActionHandlers, "/router-test")
 }
 
 func TestActionManagerHandle(t *testing.T) {
@@ -90,3 +105,195 @@ func TestActionManagerCloseUnusedHandlers(t *testing.T) {
 	m.closeUnusedHandlers()
 	require.Empty(t, m.handlers)
 }
+
+func TestRouterUseActionMiddleware(t *testing.T) {
+	r := NewRouter()
+	r.UseActionMiddleware(func(ctx Context, r *ActionRequest) {})
+	require.Len(t, r.getActionMiddlewares(), 1)
+
+	e := engine{Router: r}
+	e.init()
+	defer e.Close()
+	require.Len(t, e.ActionMiddlewares, 1)
+}
+
+func TestEngineActionMiddleware(t *testing.T) {
+	var handled []Action
+	var audited []string
+
+	e := engine{
+		ActionHandlers: map[string]ActionHandler{
+			"/test": func(ctx Context, a Action) {
+				handled = append(handled, a)
+			},
+			"/test/rerouted": func(ctx Context, a Action) {
+				handled = append(handled, a)
+			},
+		},
+		ActionMiddlewares: []ActionMiddleware{
+			func(ctx Context, r *ActionRequest) {
+				audited = append(audited, r.Action.Name)
+				r.Tag("audited", true)
+			},
+		},
+	}
+	e.init()
+	defer e.Close()
+
+	e.UseActionMiddleware(func(ctx Context, r *ActionRequest) {
+		switch r.Action.Tags.Get("route") {
+		case "reject":
+			r.Reject()
+
+		case "reroute":
+			r.Reroute("/test/rerouted")
+		}
+	})
+
+	t.Run("action is enriched", func(t *testing.T) {
+		handled = nil
+		audited = nil
+
+		e.Post(Action{Name: "/test", Value: 42})
+		e.Consume()
+		require.Equal(t, []string{"/test"}, audited)
+		require.Len(t, handled, 1)
+		require.Equal(t, "/test", handled[0].Name)
+		require.Equal(t, 42, handled[0].Value)
+		require.Equal(t, "true", handled[0].Tags.Get("audited"))
+	})
+
+	t.Run("action is rejected", func(t *testing.T) {
+		handled = nil
+		audited = nil
+
+		e.Post(Action{Name: "/test", Tags: Tags{"route": "reject"}})
+		e.Consume()
+		require.Equal(t, []string{"/test"}, audited)
+		require.Empty(t, handled)
+	})
+
+	t.Run("action is rerouted", func(t *testing.T) {
+		handled = nil
+
+		e.Post(Action{Name: "/test", Tags: Tags{"route": "reroute"}})
+		e.Consume()
+		require.Len(t, handled, 1)
+		require.Equal(t, "/test/rerouted", handled[0].Name)
+	})
+}
+
+func TestContextRequest(t *testing.T) {
+	e := engine{}
+	e.init()
+	defer e.Close()
+
+	h := &hello{}
+	e.Mount(h)
+	e.Consume()
+	ctx := makeContext(h)
+
+	e.Handle("/test/double", h, func(ctx Context, a Action) {
+		a.Reply(a.Value.(int) * 2)
+	})
+	e.Handle("/test/fail", h, func(ctx Context, a Action) {
+		a.ReplyError(errors.New("test"))
+	})
+	e.Handle("/test/ignore", h, func(ctx Context, a Action) {})
+	e.Handle("/test/multiple", h, func(ctx Context, a Action) {})
+	e.Handle("/test/multiple", e.Body, func(ctx Context, a Action) {})
+
+	t.Run("handler reply is received", func(t *testing.T) {
+		var reply int
+		var err error
+		Request(ctx, "/test/double", 21, time.Second, func(ctx Context, r int, replyErr error) {
+			reply = r
+			err = replyErr
+		})
+		e.Consume()
+		require.NoError(t, err)
+		require.Equal(t, 42, reply)
+	})
+
+	t.Run("handler error is received", func(t *testing.T) {
+		var err error
+		ctx.Request("/test/fail", nil, time.Second, func(ctx Context, r any, replyErr error) {
+			err = replyErr
+		})
+		e.Consume()
+		require.Error(t, err)
+	})
+
+	t.Run("reply with another type returns an error", func(t *testing.T) {
+		var err error
+		Request(ctx, "/test/double", 21, time.Second, func(ctx Context, r string, replyErr error) {
+			err = replyErr
+		})
+		e.Consume()
+		require.Error(t, err)
+	})
+
+	t.Run("action without handler returns an error", func(t *testing.T) {
+		var err error
+		ctx.Request("/test/unhandled", nil, time.Second, func(ctx Context, r any, replyErr error) {
+			err = replyErr
+		})
+		e.Consume()
+		require.Error(t, err)
+	})
+
+	t.Run("action with multiple handlers returns an error", func(t *testing.T) {
+		var err error
+		ctx.Request("/test/multiple", nil, time.Second, func(ctx Context, r any, replyErr error) {
+			err = replyErr
+		})
+		e.Consume()
+		require.Error(t, err)
+	})
+
+	t.Run("action without reply times out", func(t *testing.T) {
+		var err error
+		ctx.Request("/test/ignore", nil, time.Millisecond, func(ctx Context, r any, replyErr error) {
+			err = replyErr
+		})
+		e.Consume()
+		time.Sleep(time.Millisecond * 50)
+		e.Consume()
+		require.Error(t, err)
+	})
+
+	t.Run("action rejected by middleware returns an error", func(t *testing.T) {
+		e.UseActionMiddleware(func(ctx Context, r *ActionRequest) {
+			r.Reject()
+		})
+
+		var err error
+		ctx.Request("/test/double", 21, time.Second, func(ctx Context, r any, replyErr error) {
+			err = replyErr
+		})
+		e.Consume()
+		require.Error(t, err)
+	})
+}
+
+func TestActionDecodeValue(t *testing.T) {
+	t.Run("local value", func(t *testing.T) {
+		var v int
+		err := Action{Value: 42}.DecodeValue(&v)
+		require.NoError(t, err)
+		require.Equal(t, 42, v)
+	})
+
+	t.Run("json value", func(t *testing.T) {
+		var v int
+		err := Action{Value: json.RawMessage("42")}.DecodeValue(&v)
+		require.NoError(t, err)
+		require.Equal(t, 42, v)
+	})
+
+	t.Run("value with another type", func(t *testing.T) {
+		var v string
+		err := Action{Value: 42}.DecodeValue(&v)
+		require.Error(t, err)
+	})
+}
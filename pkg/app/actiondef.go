@@ -0,0 +1,95 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+// ActionDef is the definition of an action whose value is of type T. It
+// provides functions to post and handle the action where the value type is
+// checked at compile time. Eg:
+//
+//	var userSaved = app.DefineAction[User]("user/saved")
+//
+// Actions posted and handled with an ActionDef are regular actions: they go
+// through action middlewares and can be handled with untyped handlers
+// registered with the action name.
+type ActionDef[T any] struct {
+	name string
+}
+
+// DefineAction returns the definition of the action with the given name, whose
+// value is of type T.
+func DefineAction[T any](name string) ActionDef[T] {
+	return ActionDef[T]{name: name}
+}
+
+// TypedActionHandler represents a handler that is executed when an action
+// defined with DefineAction is posted. It receives the action value as a T.
+type TypedActionHandler[T any] func(ctx Context, v T, a Action)
+
+// Name returns the action name.
+func (d ActionDef[T]) Name() string {
+	return d.name
+}
+
+// Post creates the action with the given value and optional tags. Eg:
+//
+//	userSaved.Post(ctx, u)
+//	userSaved.Post(ctx, u, app.T("source", "form"))
+func (d ActionDef[T]) Post(ctx Context, v T, tags ...Tagger) {
+	ctx.NewActionWithValue(d.name, v, tags...)
+}
+
+// Handle registers the given handler for the action, for the nearest
+// component of the given context. The handler is executed on the UI goroutine
+// and is removed when the component is dismounted. Registering the action
+// more than once for a component replaces the previous handler. Eg:
+//
+//	func (l *userList) OnMount(ctx app.Context) {
+//	    userSaved.Handle(ctx, l.onUserSaved)
+//	}
+//
+//	func (l *userList) onUserSaved(ctx app.Context, u User, a app.Action) {
+//	    ...
+//	}
+func (d ActionDef[T]) Handle(ctx Context, h TypedActionHandler[T]) {
+	ctx.Handle(d.name, d.Handler(h))
+}
+
+// Handler returns an ActionHandler that calls the given handler with the
+// action value. Actions with a value that is not a T are logged and not
+// passed to the handler.
+//
+// It is used to register a typed handler with functions that take an
+// ActionHandler. Eg:
+//
+//	app.Handle(userSaved.Name(), userSaved.Handler(onUserSaved))
+func (d ActionDef[T]) Handler(h TypedActionHandler[T]) ActionHandler {
+	return func(ctx Context, a Action) {
+		v, err := d.Value(a)
+		if err != nil {
+			Log(err)
+			return
+		}
+		h(ctx, v, a)
+	}
+}
+
+// Value returns the value of the given action as a T. It returns an error
+// when the action value is not a T.
+func (d ActionDef[T]) Value(a Action) (T, error) {
+	if v, ok := a.Value.(T); ok {
+		return v, nil
+	}
+
+	var v T
+	if err := a.DecodeValue(&v); err != nil {
+		return v, errors.New("getting typed action value failed").
+			WithTag("action", a.Name).
+			WithTag("value-type", fmt.Sprintf("%T", a.Value)).
+			Wrap(err)
+	}
+	return v, nil
+}
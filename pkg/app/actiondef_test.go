@@ -0,0 +1,123 @@
+package app
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionDef(t *testing.T) {
+	type user struct {
+		Name string
+	}
+
+	e := engine{}
+	e.init()
+	defer e.Close()
+
+	h := &hello{}
+	e.Mount(h)
+	e.Consume()
+	ctx := makeContext(h)
+
+	userSaved := DefineAction[user]("/test/user/saved")
+	require.Equal(t, "/test/user/saved", userSaved.Name())
+
+	var typed []user
+	userSaved.Handle(ctx, func(ctx Context, u user, a Action) {
+		typed = append(typed, u)
+	})
+
+	var untyped []any
+	ctx.Handle(userSaved.Name(), func(ctx Context, a Action) {
+		untyped = append(untyped, a.Value)
+	})
+
+	t.Run("typed action is handled by typed and untyped handlers", func(t *testing.T) {
+		typed = nil
+		untyped = nil
+
+		userSaved.Post(ctx, user{Name: "Maxence"})
+		e.Consume()
+		require.Equal(t, []user{{Name: "Maxence"}}, typed)
+		require.Equal(t, []any{user{Name: "Maxence"}}, untyped)
+	})
+
+	t.Run("untyped action is handled by typed handler", func(t *testing.T) {
+		typed = nil
+
+		ctx.NewActionWithValue(userSaved.Name(), &user{Name: "Jonhy"})
+		e.Consume()
+		require.Equal(t, []user{{Name: "Jonhy"}}, typed)
+	})
+
+	t.Run("action with another value type is not handled by typed handler", func(t *testing.T) {
+		typed = nil
+		untyped = nil
+
+		ctx.NewActionWithValue(userSaved.Name(), 42)
+		e.Consume()
+		require.Empty(t, typed)
+		require.Equal(t, []any{42}, untyped)
+	})
+
+	t.Run("registering typed handler again replaces previous one", func(t *testing.T) {
+		typed = nil
+
+		var replaced []user
+		userSaved.Handle(ctx, func(ctx Context, u user, a Action) {
+			replaced = append(replaced, u)
+		})
+		userSaved.Post(ctx, user{Name: "Max"})
+		e.Consume()
+		require.Empty(t, typed)
+		require.Len(t, replaced, 1)
+	})
+}
+
+func TestActionDefValue(t *testing.T) {
+	def := DefineAction[int]("/test/value")
+
+	utests := []struct {
+		scenario string
+		value    any
+		expected int
+		err      bool
+	}{
+		{
+			scenario: "value",
+			value:    42,
+			expected: 42,
+		},
+		{
+			scenario: "nil value",
+			value:    nil,
+		},
+		{
+			scenario: "json value",
+			value:    json.RawMessage("21"),
+			expected: 21,
+		},
+		{
+			scenario: "value with another type",
+			value:    "42",
+			err:      true,
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			v, err := def.Value(Action{
+				Name:  def.Name(),
+				Value: u.value,
+			})
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, u.expected, v)
+		})
+	}
+}
@@ -35,6 +35,7 @@ const (
 	orientationChangeDelay = time.Millisecond * 500
 	engineUpdateRate       = 120
 	resizeInterval         = time.Millisecond * 250
+	maxRouteRedirects      = 10
 )
 
 var (
@@ -98,7 +99,18 @@ func Window() BrowserWindow {
 //			http.Handle("/", &app.Handler{Name: "My app"})
 //			http.ListenAndServe(":8080", nil)
 //	 }
+//
+// The app uses the default router. Use Router.RunWhenOnBrowser to start the
+// app with another router.
 func RunWhenOnBrowser() {
+	defaultRouter.RunWhenOnBrowser()
+}
+
+// RunWhenOnBrowser starts the app with the router, displaying the component
+// associated with the current URL path.
+//
+// This call is skipped when the program is not run on a web browser.
+func (r *Router) RunWhenOnBrowser() {
 	if IsServer {
 		return
 	}
@@ -117,13 +129,18 @@ func RunWhenOnBrowser() {
 		FrameRate:              engineUpdateRate,
 		LocalStorage:           newJSStorage("localStorage"),
 		SessionStorage:         newJSStorage("sessionStorage"),
+		StateStorage:           stateStorage,
+		ServerActionsURL:       serverURL(Getenv("GOAPP_SERVER_ACTIONS_URL")),
+		StateSyncURL:           Getenv("GOAPP_STATE_SYNC_URL"),
 		StaticResourceResolver: staticResourcesResolver,
-		ActionHandlers:         actionHandlers,
+		Router:                 r,
+		Hydrate:                Getenv("GOAPP_HYDRATE") == "true",
 	}
 	disp.Page = browserPage{dispatcher: &disp}
 	disp.Body = newClientBody(&disp)
 	disp.init()
 	defer disp.Close()
+	loadTransferredStates(&disp)
 
 	window.setBody(disp.Body)
 
@@ -157,6 +174,31 @@ func RunWhenOnBrowser() {
 	disp.start(context.Background())
 }
 
+func loadTransferredStates(e *engine) {
+	states := Window().GetElementByID("app-states")
+	if !states.Truthy() {
+		return
+	}
+
+	if err := e.states.loadTransferredStates(states.Get("textContent").String()); err != nil {
+		Log(errors.New("loading transferred states failed").Wrap(err))
+	}
+}
+
+// serverURL returns the absolute URL of the given path on the server that
+// serves the app.
+func serverURL(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	u := *Window().URL()
+	u.Path = path
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
 func displayLoadError(err any) {
 	loadingLabel := Window().
 		Get("document").
@@ -165,6 +207,12 @@ func displayLoadError(err any) {
 		return
 	}
 	loadingLabel.setInnerText(fmt.Sprint(err))
+
+	// The loader is hidden when the pre-rendered markup is hydrated.
+	loader := Window().GetElementByID("app-wasm-loader")
+	if loader.Truthy() {
+		loader.Get("style").Set("display", "")
+	}
 }
 
 func newClientStaticResourceResolver(staticResourceURL string) func(string) string {
@@ -346,24 +394,51 @@ func performNavigate(d Dispatcher, u *url.URL, updateHistory bool) {
 		return
 	}
 
-	path := strings.TrimPrefix(u.Path, rootPrefix)
-	if path == "" {
-		path = "/"
-	}
-	compo, ok := routes.createComponent(path)
-	if !ok {
-		compo = &notFound{}
-	}
-
 	disp, ok := d.(ClientDispatcher)
 	if !ok {
 		return
 	}
+
+	var compo Composer
+	isRedirected := false
+	for redirects := 0; ; redirects++ {
+		req := RouteRequest{URL: u}
+		compo = d.getRouter().handle(disp.Context(), &req)
+		if req.redirectURL == "" {
+			break
+		}
+
+		redirectURL, err := u.Parse(req.redirectURL)
+		if err != nil || redirects >= maxRouteRedirects {
+			Log(errors.New("redirecting navigation failed").
+				WithTag("url", u).
+				WithTag("redirect-url", req.redirectURL).
+				WithTag("redirects", redirects).
+				Wrap(err))
+			compo = nil
+			break
+		}
+
+		if isExternalNavigation(redirectURL) {
+			Window().Get("location").Set("href", redirectURL.String())
+			return
+		}
+		u = redirectURL
+		isRedirected = true
+	}
+	if compo == nil {
+		compo = &notFound{}
+	}
 	disp.Mount(compo)
 
-	if updateHistory {
+	switch {
+	case updateHistory:
 		Window().addHistory(u)
-	} else {
+
+	case isRedirected:
+		Window().replaceHistory(u)
+
+	default:
 		lastURLVisited = u
 	}
 
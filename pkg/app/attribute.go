@@ -1,12 +1,24 @@
 package app
 
 import (
+	"sort"
 	"strconv"
 	"strings"
 )
 
 type attributes map[string]string
 
+// names returns the attribute names sorted in alphabetical order, which makes
+// the rendered HTML identical across renderings.
+func (a attributes) names() []string {
+	names := make([]string, 0, len(a))
+	for k := range a {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (a attributes) Set(name string, value any) {
 	switch name {
 	case "style", "allow":
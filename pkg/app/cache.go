@@ -2,6 +2,10 @@ package app
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -20,7 +24,8 @@ type PreRenderCache interface {
 
 // PreRenderedItem represent an item that is stored in a PreRenderCache.
 type PreRenderedItem struct {
-	// The request path.
+	// The request path. For pre-rendered pages, this is the key returned by
+	// Handler.PreRenderCacheKey.
 	Path string
 
 	// The response content type.
@@ -32,6 +37,14 @@ type PreRenderedItem struct {
 	// The cache control.
 	CacheControl string
 
+	// The response status code.
+	//
+	// Default: 200.
+	StatusCode int
+
+	// The additional response headers.
+	Header http.Header
+
 	// The response body.
 	Body []byte
 }
@@ -97,3 +110,75 @@ func (c *preRenderCache) Get(ctx context.Context, path string) (PreRenderedItem,
 	c.mu.Unlock()
 	return i, ok
 }
+
+// PreRenderCacheVariants describes the request attributes that, in addition to
+// the URL path, distinguish the pages stored in a pre-render cache. It is set
+// with Handler.PreRenderCacheVariants:
+//
+//	h := &app.Handler{
+//	    PreRenderCacheVariants: app.PreRenderCacheVariants{
+//	        Query:   true,
+//	        Headers: []string{"Accept-Language"},
+//	        Cookies: []string{"session"},
+//	    },
+//	}
+type PreRenderCacheVariants struct {
+	// Reports whether the URL query is part of the key.
+	Query bool
+
+	// Reports whether the request host is part of the key.
+	Host bool
+
+	// The request headers that are part of the key.
+	Headers []string
+
+	// The cookies that are part of the key. Cookie values are hashed.
+	Cookies []string
+}
+
+// vary returns the value of the Vary header of the pages that depend on the
+// variants.
+func (v PreRenderCacheVariants) vary() string {
+	vary := make([]string, 0, len(v.Headers)+1)
+	for _, h := range v.Headers {
+		vary = append(vary, http.CanonicalHeaderKey(h))
+	}
+	if len(v.Cookies) != 0 {
+		vary = append(vary, "Cookie")
+	}
+	return strings.Join(vary, ", ")
+}
+
+// Key returns the pre-render cache key for the given request.
+func (v PreRenderCacheVariants) Key(r *http.Request) string {
+	var b strings.Builder
+
+	if v.Host {
+		b.WriteString(r.Host)
+	}
+	b.WriteString(r.URL.Path)
+
+	if query := r.URL.Query(); v.Query && len(query) != 0 {
+		b.WriteByte('?')
+		b.WriteString(query.Encode())
+	}
+
+	for _, h := range v.Headers {
+		b.WriteString("\n")
+		b.WriteString(http.CanonicalHeaderKey(h))
+		b.WriteString(": ")
+		b.WriteString(strings.Join(r.Header.Values(h), ", "))
+	}
+
+	for _, name := range v.Cookies {
+		b.WriteString("\ncookie ")
+		b.WriteString(name)
+		b.WriteByte('=')
+		if c, err := r.Cookie(name); err == nil {
+			sum := sha256.Sum256([]byte(c.Value))
+			b.WriteString(hex.EncodeToString(sum[:8]))
+		}
+	}
+
+	return b.String()
+}
@@ -2,6 +2,8 @@ package app
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -134,3 +136,93 @@ func TestPreRenderLRUCacheEvict(t *testing.T) {
 	require.Equal(t, 3, evictCount)
 	require.Equal(t, 12, evictSize)
 }
+
+func TestPreRenderCacheVariantsKey(t *testing.T) {
+	newRequest := func(target string, header map[string]string, cookies ...*http.Cookie) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for k, v := range header {
+			r.Header.Set(k, v)
+		}
+		for _, c := range cookies {
+			r.AddCookie(c)
+		}
+		return r
+	}
+
+	utests := []struct {
+		scenario string
+		variants PreRenderCacheVariants
+		a        *http.Request
+		b        *http.Request
+		sameKey  bool
+	}{
+		{
+			scenario: "query is ignored",
+			a:        newRequest("/search?q=a", nil),
+			b:        newRequest("/search?q=b", nil),
+			sameKey:  true,
+		},
+		{
+			scenario: "query is part of the key",
+			variants: PreRenderCacheVariants{Query: true},
+			a:        newRequest("/search?q=a", nil),
+			b:        newRequest("/search?q=b", nil),
+		},
+		{
+			scenario: "query order is ignored",
+			variants: PreRenderCacheVariants{Query: true},
+			a:        newRequest("/search?q=a&page=1", nil),
+			b:        newRequest("/search?page=1&q=a", nil),
+			sameKey:  true,
+		},
+		{
+			scenario: "host is part of the key",
+			variants: PreRenderCacheVariants{Host: true},
+			a:        newRequest("http://a.com/", nil),
+			b:        newRequest("http://b.com/", nil),
+		},
+		{
+			scenario: "header is part of the key",
+			variants: PreRenderCacheVariants{Headers: []string{"accept-language"}},
+			a:        newRequest("/", map[string]string{"Accept-Language": "en"}),
+			b:        newRequest("/", map[string]string{"Accept-Language": "fr"}),
+		},
+		{
+			scenario: "not selected header is ignored",
+			variants: PreRenderCacheVariants{Headers: []string{"Accept-Language"}},
+			a:        newRequest("/", map[string]string{"X-Foo": "a"}),
+			b:        newRequest("/", map[string]string{"X-Foo": "b"}),
+			sameKey:  true,
+		},
+		{
+			scenario: "cookie is part of the key",
+			variants: PreRenderCacheVariants{Cookies: []string{"session"}},
+			a:        newRequest("/", nil, &http.Cookie{Name: "session", Value: "a"}),
+			b:        newRequest("/", nil, &http.Cookie{Name: "session", Value: "b"}),
+		},
+		{
+			scenario: "missing cookie is part of the key",
+			variants: PreRenderCacheVariants{Cookies: []string{"session"}},
+			a:        newRequest("/", nil, &http.Cookie{Name: "session", Value: "a"}),
+			b:        newRequest("/", nil),
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			a := u.variants.Key(u.a)
+			b := u.variants.Key(u.b)
+			if u.sameKey {
+				require.Equal(t, a, b)
+				return
+			}
+			require.NotEqual(t, a, b)
+		})
+	}
+
+	t.Run("cookie value is hashed", func(t *testing.T) {
+		r := newRequest("/", nil, &http.Cookie{Name: "session", Value: "secret"})
+		key := PreRenderCacheVariants{Cookies: []string{"session"}}.Key(r)
+		require.NotContains(t, key, "secret")
+	})
+}
@@ -49,6 +49,8 @@ type Composer interface {
 
 	updateRoot() error
 	dispatch(func(Context))
+	getOutlet() Composer
+	setOutlet(Composer)
 }
 
 // PreRenderer is the interface that describes a component that performs
@@ -103,6 +105,20 @@ type Navigator interface {
 	OnNav(Context)
 }
 
+// Keyer is the interface that describes a component that provides a key to
+// identify itself among its siblings.
+//
+// When a parent element is updated, keyed components are matched by key rather
+// than by position. A component that changes position within a list is then
+// moved and stays mounted instead of being replaced.
+type Keyer interface {
+	Composer
+
+	// The function that returns the component key. It must be unique among the
+	// component siblings.
+	Key() string
+}
+
 // Updater is the interface that describes a component that can do additional
 // instructions when one of its exported fields is modified by its nearest
 // parent component.
@@ -153,6 +169,7 @@ type Compo struct {
 	parentElem UI
 	root       UI
 	this       Composer
+	outlet     Composer
 }
 
 // Kind returns the ui element kind.
@@ -232,6 +249,13 @@ func (c *Compo) name() string {
 	return name
 }
 
+func (c *Compo) getKey() string {
+	if keyer, ok := c.self().(Keyer); ok {
+		return keyer.Key()
+	}
+	return ""
+}
+
 func (c *Compo) self() UI {
 	return c.this
 }
@@ -269,11 +293,31 @@ func (c *Compo) setParent(p UI) {
 	c.parentElem = p
 }
 
+func (c *Compo) getOutlet() Composer {
+	return c.outlet
+}
+
+func (c *Compo) setOutlet(v Composer) {
+	c.outlet = v
+}
+
 func (c *Compo) getChildren() []UI {
 	return []UI{c.root}
 }
 
 func (c *Compo) mount(d Dispatcher) error {
+	return c.mountWith(d, func(root UI) error {
+		return mount(d, root)
+	})
+}
+
+func (c *Compo) hydrate(d Dispatcher, cursor *domCursor) error {
+	return c.mountWith(d, func(root UI) error {
+		return hydrate(d, root, cursor)
+	})
+}
+
+func (c *Compo) mountWith(d Dispatcher, mountRoot func(UI) error) error {
 	if c.Mounted() {
 		return errors.New("mounting component failed").
 			WithTag("reason", "already mounted").
@@ -289,7 +333,7 @@ func (c *Compo) mount(d Dispatcher) error {
 	c.ctx, c.ctxCancel = context.WithCancel(context.Background())
 
 	root := c.render()
-	if err := mount(d, root); err != nil {
+	if err := mountRoot(root); err != nil {
 		return errors.New("mounting component failed").
 			WithTag("name", c.name()).
 			WithTag("kind", c.Kind()).
@@ -338,10 +382,14 @@ func (c *Compo) updateWith(v UI) error {
 			WithTag("new", reflect.TypeOf(v))
 	}
 
+	haveModifiedFields, err := c.updateOutlet(v.(Composer).getOutlet())
+	if err != nil {
+		return errors.New("updating outlet failed").Wrap(err)
+	}
+
 	aval := reflect.Indirect(reflect.ValueOf(c.self()))
 	bval := reflect.Indirect(reflect.ValueOf(v))
 	compotype := reflect.ValueOf(c).Elem().Type()
-	haveModifiedFields := false
 
 	for i := 0; i < aval.NumField(); i++ {
 		a := aval.Field(i)
@@ -377,6 +425,23 @@ func (c *Compo) updateWith(v UI) error {
 	return nil
 }
 
+// updateOutlet updates the component displayed by a layout with the given
+// one. The mounted outlet component is kept when it can be updated, which
+// preserves the state of nested layouts. It reports whether the layout needs
+// to be rendered again.
+func (c *Compo) updateOutlet(v Composer) (bool, error) {
+	if v == nil || v == c.outlet {
+		return false, nil
+	}
+
+	if c.outlet != nil && canUpdate(c.outlet, v) {
+		return false, update(c.outlet, v)
+	}
+
+	c.outlet = v
+	return true, nil
+}
+
 func (c *Compo) dispatch(fn func(Context)) {
 	c.getDispatcher().Dispatch(Dispatch{
 		Mode:     Update,
@@ -410,14 +475,7 @@ func (c *Compo) replaceRoot(v UI) error {
 			Wrap(err)
 	}
 
-	var parent UI
-	for {
-		parent = c.getParent()
-		if parent == nil || parent.Kind() == HTML {
-			break
-		}
-	}
-
+	parent := getJSParent(c)
 	if parent == nil {
 		return errors.New("replacing component root failed").
 			WithTag("kind", c.Kind()).
@@ -428,9 +486,8 @@ func (c *Compo) replaceRoot(v UI) error {
 	c.root = new
 	new.setParent(c.self())
 
-	oldjs := old.JSValue()
-	newjs := v.JSValue()
-	parent.JSValue().replaceChild(newjs, oldjs)
+	insertJSNodes(parent, new, firstJSNode(old))
+	removeJSNodes(parent, old)
 
 	dismount(old)
 	return nil
@@ -438,7 +495,10 @@ func (c *Compo) replaceRoot(v UI) error {
 
 func (c *Compo) render() UI {
 	elems := FilterUIElems(c.this.Render())
-	return elems[0]
+	if len(elems) == 1 {
+		return elems[0]
+	}
+	return &fragment{children: elems}
 }
 
 func (c *Compo) onComponentEvent(le any) {
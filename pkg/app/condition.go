@@ -72,6 +72,10 @@ func (c condition) name() string {
 	return "if.else"
 }
 
+func (c condition) getKey() string {
+	return ""
+}
+
 func (c condition) self() UI {
 	return c
 }
@@ -112,6 +116,12 @@ func (c condition) mount(Dispatcher) error {
 		WithTag("kind", c.Kind())
 }
 
+func (c condition) hydrate(Dispatcher, *domCursor) error {
+	return errors.New("condition is not hydratable").
+		WithTag("name", c.name()).
+		WithTag("kind", c.Kind())
+}
+
 func (c condition) dismount() {
 }
 
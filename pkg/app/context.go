@@ -40,6 +40,14 @@ type Context interface {
 	// Returns the current page.
 	Page() Page
 
+	// Returns the value of the named parameter from the route that matches
+	// the current page URL. Eg:
+	//  app.Route("/users/{id}", &user{})
+	//  ctx.Param("id") // Returns "42" when the page URL is "/users/42".
+	//
+	// An empty string is returned when the parameter does not exist.
+	Param(name string) string
+
 	// Executes the given function on the UI goroutine and notifies the
 	// context's nearest component to update its state.
 	Dispatch(fn func(Context))
@@ -72,6 +80,35 @@ type Context interface {
 	//  })
 	NewActionWithValue(name string, v any, tags ...Tagger)
 
+	// Creates an action with a value and optional tags that expects a reply
+	// from its handler, which replies with Action.Reply or Action.ReplyError.
+	// The given function is executed on the UI goroutine with the replied
+	// value, or with an error when the action has no handler or more than
+	// one, when the handler replies an error, or when the context is canceled
+	// or the timeout is reached before a reply is received. A zero timeout
+	// waits until the context is canceled. Eg:
+	//  ctx.Request("form/is-dirty", nil, time.Second, func(ctx app.Context, reply any, err error) {
+	//      isDirty, _ := reply.(bool)
+	//      ...
+	//  })
+	//
+	// The Request function can be used to get a reply of a given type.
+	Request(name string, v any, timeout time.Duration, onReply func(ctx Context, reply any, err error), tags ...Tagger)
+
+	// Calls the server handler of the given action, registered with
+	// HandleServer, with a value and optional tags. The given function is
+	// executed on the UI goroutine with the JSON encoded value returned by the
+	// handler, or with an error when the action has no server handler, when
+	// the handler returns an error, or when the context is canceled or the
+	// timeout is reached before the handler returns. A zero timeout waits until
+	// the context is canceled. Eg:
+	//  ctx.CallServer("user/save", u, 5*time.Second, func(ctx app.Context, reply json.RawMessage, err error) {
+	//      ...
+	//  })
+	//
+	// The CallServer function can be used to get a reply of a given type.
+	CallServer(name string, v any, timeout time.Duration, onReply func(ctx Context, reply json.RawMessage, err error), tags ...Tagger)
+
 	// Executes the given function on a new goroutine.
 	//
 	// The difference versus just launching a goroutine is that it ensures that
@@ -83,6 +120,25 @@ type Context interface {
 	// function.
 	After(d time.Duration, fn func(Context))
 
+	// Dispatches the given function once the given duration elapsed without
+	// another call with the same key from the context's nearest component. The
+	// function is executed on the UI goroutine, unless the component is
+	// dismounted before. Eg:
+	//  func (s *searchBox) onInput(ctx app.Context, e app.Event) {
+	//      query := ctx.JSSrc().Get("value").String()
+	//      ctx.Debounce("search", 300*time.Millisecond, func(ctx app.Context) {
+	//          s.search(ctx, query)
+	//      })
+	//  }
+	Debounce(key string, d time.Duration, fn func(Context))
+
+	// Dispatches the given function immediately, then at most once per given
+	// duration for the calls with the same key from the context's nearest
+	// component. The last function called within a duration is executed when
+	// the duration elapses. Functions are executed on the UI goroutine, unless
+	// the component is dismounted before.
+	Throttle(key string, d time.Duration, fn func(Context))
+
 	// Executes the given function and notifies the parent components to update
 	// their state. It should be used to launch component custom event handlers.
 	Emit(fn func())
@@ -139,6 +195,24 @@ type Context interface {
 	// Deletes the given state. All value observations are stopped.
 	DelState(state string)
 
+	// Declares a state whose value is computed by the given function from the
+	// given source states. The value is recomputed lazily, the next time the
+	// state is read or observed after one of its sources changed. Derived
+	// states are read and observed like any other state, and can be used as
+	// sources of other derived states. They cannot be set.
+	// Example:
+	//  ctx.DeriveState("/total", func(ctx app.Context) any {
+	//      var items []item
+	//      ctx.GetState("/cart", &items)
+	//
+	//      var total float64
+	//      for _, i := range items {
+	//          total += i.Price
+	//      }
+	//      return total
+	//  }, "/cart")
+	DeriveState(state string, fn func(Context) any, sources ...string)
+
 	// Creates an observer that observes changes for the given state.
 	// Example:
 	//  type myComponent struct {
@@ -152,6 +226,68 @@ type Context interface {
 	//  }
 	ObserveState(state string) Observer
 
+	// Creates an observer that observes changes for the states whose name
+	// matches the given pattern. The pattern is either a glob like
+	// "/cart/items/*", or a prefix followed by "**" like "/cart/**".
+	// Example:
+	//  func (c *cartSummary) OnMount(ctx app.Context) {
+	//      ctx.ObserveStates("/cart/items/*").OnChange(func(ch app.StateChange) {
+	//          var item cartItem
+	//          ch.Value(&item)
+	//          c.items[ch.State] = item
+	//      })
+	//  }
+	ObserveStates(pattern string) PatternObserver
+
+	// Returns the sorted names of the states whose name matches the given
+	// pattern. Persisted states are listed when the state storage can list its
+	// keys, which is the case of local storage and IndexedDB.
+	ListStates(pattern string) []string
+
+	// Deletes the states whose name matches the given pattern.
+	DelStates(pattern string)
+
+	// Calls the given function with a batch where state changes are recorded.
+	// When the function returns, the changes are applied atomically and the
+	// observers of each modified state are notified once with its final value.
+	// No change is applied when the function returns an error, which is
+	// returned.
+	// Example:
+	//  err := ctx.BatchStates(func(b app.StateBatch) error {
+	//      var qty int
+	//      b.Get("/cart/qty", &qty)
+	//      if qty >= 10 {
+	//          return errors.New("cart is full")
+	//      }
+	//
+	//      b.Set("/cart/qty", qty+1)
+	//      b.Set("/cart/updated-at", time.Now())
+	//      return nil
+	//  })
+	BatchStates(fn func(StateBatch) error) error
+
+	// Starts recording the changes made with SetState, DelState and DelStates
+	// in a history that keeps at most the given number of changes. States that
+	// are encrypted or whose name matches one of the exclude patterns are not
+	// recorded. The history is disabled when size is zero or less.
+	// Example:
+	//  ctx.EnableStateHistory(100, "/mouse/**")
+	EnableStateHistory(size int, exclude ...string)
+
+	// Returns the changes recorded in the state history, from the oldest to the
+	// most recent.
+	StateHistory() []StateHistoryEntry
+
+	// Reverts the most recent recorded change of a state whose name matches
+	// the given pattern. It reports whether a change was undone.
+	// Example:
+	//  ctx.UndoState("/editor/**")
+	UndoState(pattern string) bool
+
+	// Reapplies the most recent undone change of a state whose name matches
+	// the given pattern. It reports whether a change was redone.
+	RedoState(pattern string) bool
+
 	// Returns the app dispatcher.
 	Dispatcher() Dispatcher
 
@@ -208,6 +344,10 @@ func (ctx uiContext) Page() Page {
 	return ctx.page
 }
 
+func (ctx uiContext) Param(name string) string {
+	return ctx.Dispatcher().getRouter().params(routePath(ctx.Page().URL()))[name]
+}
+
 func (ctx uiContext) Dispatch(fn func(Context)) {
 	ctx.Dispatcher().Dispatch(Dispatch{
 		Mode:     Update,
@@ -233,21 +373,49 @@ func (ctx uiContext) NewAction(name string, tags ...Tagger) {
 }
 
 func (ctx uiContext) NewActionWithValue(name string, v any, tags ...Tagger) {
-	var tagMap Tags
-	for _, t := range tags {
-		if tagMap == nil {
-			tagMap = t.Tags()
-			continue
-		}
-		for k, v := range t.Tags() {
-			tagMap[k] = v
-		}
+	ctx.Dispatcher().Post(Action{
+		Name:  name,
+		Value: v,
+		Tags:  mergeTags(tags...),
+	})
+}
+
+func (ctx uiContext) Request(name string, v any, timeout time.Duration, onReply func(ctx Context, reply any, err error), tags ...Tagger) {
+	requestCtx, cancel := context.Context(ctx), func() {}
+	if timeout > 0 {
+		requestCtx, cancel = context.WithTimeout(ctx, timeout)
 	}
 
-	ctx.Dispatcher().Post(Action{
+	ctx.Dispatcher().Request(requestCtx, Action{
 		Name:  name,
 		Value: v,
-		Tags:  tagMap,
+		Tags:  mergeTags(tags...),
+	}, func(reply any, err error) {
+		cancel()
+		ctx.Dispatch(func(ctx Context) {
+			onReply(ctx, reply, err)
+		})
+	})
+}
+
+func (ctx uiContext) CallServer(name string, v any, timeout time.Duration, onReply func(ctx Context, reply json.RawMessage, err error), tags ...Tagger) {
+	a := Action{
+		Name:  name,
+		Value: v,
+		Tags:  mergeTags(tags...),
+	}
+
+	ctx.Async(func() {
+		callCtx, cancel := context.Context(ctx), func() {}
+		if timeout > 0 {
+			callCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		defer cancel()
+
+		reply, err := ctx.Dispatcher().CallServer(callCtx, a)
+		ctx.Dispatch(func(ctx Context) {
+			onReply(ctx, reply, err)
+		})
 	})
 }
 
@@ -262,6 +430,14 @@ func (ctx uiContext) After(d time.Duration, fn func(Context)) {
 	})
 }
 
+func (ctx uiContext) Debounce(key string, d time.Duration, fn func(Context)) {
+	ctx.Dispatcher().Debounce(ctx.Src(), key, d, fn)
+}
+
+func (ctx uiContext) Throttle(key string, d time.Duration, fn func(Context)) {
+	ctx.Dispatcher().Throttle(ctx.Src(), key, d, fn)
+}
+
 func (ctx uiContext) Emit(fn func()) {
 	ctx.Dispatcher().Emit(ctx.Src(), fn)
 }
@@ -358,6 +534,42 @@ func (ctx uiContext) DelState(state string) {
 	ctx.Dispatcher().DelState(state)
 }
 
+func (ctx uiContext) ObserveStates(pattern string) PatternObserver {
+	return ctx.Dispatcher().ObserveStates(pattern, ctx.src)
+}
+
+func (ctx uiContext) ListStates(pattern string) []string {
+	return ctx.Dispatcher().ListStates(pattern)
+}
+
+func (ctx uiContext) DelStates(pattern string) {
+	ctx.Dispatcher().DelStates(pattern)
+}
+
+func (ctx uiContext) BatchStates(fn func(StateBatch) error) error {
+	return ctx.Dispatcher().BatchStates(fn)
+}
+
+func (ctx uiContext) EnableStateHistory(size int, exclude ...string) {
+	ctx.Dispatcher().EnableStateHistory(size, exclude...)
+}
+
+func (ctx uiContext) StateHistory() []StateHistoryEntry {
+	return ctx.Dispatcher().StateHistory()
+}
+
+func (ctx uiContext) UndoState(pattern string) bool {
+	return ctx.Dispatcher().UndoState(pattern)
+}
+
+func (ctx uiContext) RedoState(pattern string) bool {
+	return ctx.Dispatcher().RedoState(pattern)
+}
+
+func (ctx uiContext) DeriveState(state string, fn func(Context) any, sources ...string) {
+	ctx.Dispatcher().DeriveState(state, fn, sources...)
+}
+
 func (ctx uiContext) ObserveState(state string) Observer {
 	return ctx.Dispatcher().ObserveState(state, ctx.src)
 }
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// dispatchLimiter limits the rate at which functions are dispatched. Limited
+// dispatches are identified by a key within a component, and are canceled
+// when the component is dismounted.
+type dispatchLimiter struct {
+	once     sync.Once
+	mutex    sync.Mutex
+	timers   map[dispatchTimerKey]*dispatchTimer
+	watching map[context.Context]struct{}
+
+	// The function that calls the given function once the given duration
+	// elapsed.
+	//
+	// Default: time.AfterFunc.
+	afterFunc func(time.Duration, func()) stopper
+}
+
+// stopper is the interface that describes a timer that can be stopped.
+type stopper interface {
+	Stop() bool
+}
+
+type dispatchTimerKey struct {
+	component Composer
+	key       string
+}
+
+type dispatchTimer struct {
+	timer    stopper
+	ctx      context.Context
+	pending  Dispatch
+	duration time.Duration
+}
+
+func (l *dispatchLimiter) init() {
+	l.timers = make(map[dispatchTimerKey]*dispatchTimer)
+	l.watching = make(map[context.Context]struct{})
+
+	if l.afterFunc == nil {
+		l.afterFunc = func(d time.Duration, fn func()) stopper {
+			return time.AfterFunc(d, fn)
+		}
+	}
+}
+
+// debounce dispatches the given function once the given duration elapsed
+// without another call with the same key.
+func (l *dispatchLimiter) debounce(src UI, key string, d time.Duration, fn func(Context)) {
+	l.once.Do(l.init)
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	k, ctx, ok := l.timerKey(src, key)
+	if !ok {
+		return
+	}
+
+	if t, ok := l.timers[k]; ok {
+		t.timer.Stop()
+	}
+
+	t := &dispatchTimer{
+		ctx:     ctx,
+		pending: makeLimitedDispatch(src, fn),
+	}
+	t.timer = l.afterFunc(d, func() {
+		l.mutex.Lock()
+		if l.timers[k] != t {
+			l.mutex.Unlock()
+			return
+		}
+		delete(l.timers, k)
+		l.mutex.Unlock()
+
+		src.getDispatcher().Dispatch(t.pending)
+	})
+	l.timers[k] = t
+}
+
+// throttle dispatches the given function immediately, then at most once per
+// given duration for the calls with the same key. The last function called
+// within a duration is dispatched when the duration elapses.
+func (l *dispatchLimiter) throttle(src UI, key string, d time.Duration, fn func(Context)) {
+	l.once.Do(l.init)
+	l.mutex.Lock()
+
+	k, ctx, ok := l.timerKey(src, key)
+	if !ok {
+		l.mutex.Unlock()
+		return
+	}
+
+	if t, ok := l.timers[k]; ok {
+		t.pending = makeLimitedDispatch(src, fn)
+		l.mutex.Unlock()
+		return
+	}
+
+	t := &dispatchTimer{
+		ctx:      ctx,
+		duration: d,
+	}
+	t.timer = l.afterFunc(d, func() { l.onThrottleElapsed(k, t) })
+	l.timers[k] = t
+	l.mutex.Unlock()
+
+	src.getDispatcher().Dispatch(makeLimitedDispatch(src, fn))
+}
+
+// onThrottleElapsed dispatches the last function called while the throttle
+// duration elapsed, and starts a new duration. The throttle ends when no
+// function was called.
+func (l *dispatchLimiter) onThrottleElapsed(k dispatchTimerKey, t *dispatchTimer) {
+	l.mutex.Lock()
+	if l.timers[k] != t {
+		l.mutex.Unlock()
+		return
+	}
+
+	pending := t.pending
+	if pending.Function == nil {
+		delete(l.timers, k)
+		l.mutex.Unlock()
+		return
+	}
+	t.pending = Dispatch{}
+	t.timer = l.afterFunc(t.duration, func() { l.onThrottleElapsed(k, t) })
+	l.mutex.Unlock()
+
+	pending.Source.getDispatcher().Dispatch(pending)
+}
+
+// timerKey returns the key of the timer associated with the component of the
+// given element, with the component context. It reports false when the
+// component is not mounted.
+func (l *dispatchLimiter) timerKey(src UI, key string) (dispatchTimerKey, context.Context, bool) {
+	compo := getComponent(src)
+	if compo == nil || !compo.Mounted() {
+		return dispatchTimerKey{}, nil, false
+	}
+
+	ctx := compo.getContext()
+	if _, ok := l.watching[ctx]; !ok {
+		l.watching[ctx] = struct{}{}
+		go l.cancelWhenDone(ctx)
+	}
+
+	return dispatchTimerKey{
+		component: compo,
+		key:       key,
+	}, ctx, true
+}
+
+// cancelWhenDone stops the timers created with the given component context
+// once it is canceled, which happens when the component is dismounted.
+func (l *dispatchLimiter) cancelWhenDone(ctx context.Context) {
+	<-ctx.Done()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for k, t := range l.timers {
+		if t.ctx == ctx {
+			t.timer.Stop()
+			delete(l.timers, k)
+		}
+	}
+	delete(l.watching, ctx)
+}
+
+func makeLimitedDispatch(src UI, fn func(Context)) Dispatch {
+	return Dispatch{
+		Mode:     Update,
+		Source:   src,
+		Function: fn,
+	}
+}
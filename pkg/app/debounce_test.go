@@ -0,0 +1,204 @@
+package app
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContextDebounce(t *testing.T) {
+	clock := &testClock{}
+	e := engine{}
+	e.limiter.afterFunc = clock.afterFunc
+	e.init()
+	defer e.Close()
+
+	h := &hello{}
+	e.Mount(h)
+	e.Consume()
+	ctx := makeContext(h)
+
+	t.Run("last call is dispatched once", func(t *testing.T) {
+		var calls []int
+		for i := 1; i <= 3; i++ {
+			i := i
+			ctx.Debounce("test", time.Millisecond*20, func(ctx Context) {
+				calls = append(calls, i)
+			})
+			clock.advance(time.Millisecond * 10)
+		}
+		e.Consume()
+		require.Empty(t, calls)
+
+		clock.advance(time.Millisecond * 10)
+		e.Consume()
+		require.Equal(t, []int{3}, calls)
+
+		clock.advance(time.Millisecond * 20)
+		e.Consume()
+		require.Equal(t, []int{3}, calls)
+	})
+
+	t.Run("calls with different keys are dispatched", func(t *testing.T) {
+		var calls []string
+		ctx.Debounce("a", time.Millisecond, func(ctx Context) {
+			calls = append(calls, "a")
+		})
+		ctx.Debounce("b", time.Millisecond, func(ctx Context) {
+			calls = append(calls, "b")
+		})
+
+		clock.advance(time.Millisecond)
+		e.Consume()
+		require.Equal(t, []string{"a", "b"}, calls)
+	})
+}
+
+func TestContextThrottle(t *testing.T) {
+	clock := &testClock{}
+	e := engine{}
+	e.limiter.afterFunc = clock.afterFunc
+	e.init()
+	defer e.Close()
+
+	h := &hello{}
+	e.Mount(h)
+	e.Consume()
+	ctx := makeContext(h)
+
+	var calls []int
+	throttle := func(i int) {
+		ctx.Throttle("test", time.Millisecond*30, func(ctx Context) {
+			calls = append(calls, i)
+		})
+	}
+
+	throttle(1)
+	e.Consume()
+	require.Equal(t, []int{1}, calls)
+
+	throttle(2)
+	clock.advance(time.Millisecond * 29)
+	throttle(3)
+	e.Consume()
+	require.Equal(t, []int{1}, calls)
+
+	clock.advance(time.Millisecond)
+	e.Consume()
+	require.Equal(t, []int{1, 3}, calls)
+
+	clock.advance(time.Millisecond * 60)
+	e.Consume()
+	require.Equal(t, []int{1, 3}, calls)
+
+	throttle(4)
+	e.Consume()
+	require.Equal(t, []int{1, 3, 4}, calls)
+}
+
+func TestDispatchLimiterCancelOnDismount(t *testing.T) {
+	clock := &testClock{}
+	e := engine{}
+	e.limiter.afterFunc = clock.afterFunc
+	e.init()
+	defer e.Close()
+
+	h := &hello{}
+	e.Mount(h)
+	e.Consume()
+	ctx := makeContext(h)
+
+	isCalled := false
+	ctx.Debounce("test", time.Millisecond*20, func(ctx Context) {
+		isCalled = true
+	})
+	ctx.Throttle("test", time.Millisecond*20, func(ctx Context) {})
+
+	e.Mount(Div())
+	e.Consume()
+	require.False(t, h.Mounted())
+
+	clock.advance(time.Millisecond * 40)
+	e.Consume()
+	require.False(t, isCalled)
+
+	// Timers are removed from another goroutine once the component context is
+	// canceled.
+	for {
+		e.limiter.mutex.Lock()
+		isCleared := len(e.limiter.timers) == 0 && len(e.limiter.watching) == 0
+		e.limiter.mutex.Unlock()
+		if isCleared {
+			break
+		}
+		runtime.Gosched()
+	}
+}
+
+// testClock is a clock whose time only passes when it is advanced, which
+// makes the timers it creates fire deterministically.
+type testClock struct {
+	mutex  sync.Mutex
+	now    time.Duration
+	timers []*testTimer
+}
+
+type testTimer struct {
+	clock     *testClock
+	at        time.Duration
+	fn        func()
+	isStopped bool
+}
+
+func (c *testClock) afterFunc(d time.Duration, fn func()) stopper {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	t := &testTimer{
+		clock: c,
+		at:    c.now + d,
+		fn:    fn,
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// advance moves the time forward by the given duration, calling the functions
+// of the timers that expire in their expiration order.
+func (c *testClock) advance(d time.Duration) {
+	c.mutex.Lock()
+	end := c.now + d
+
+	for {
+		var next *testTimer
+		for _, t := range c.timers {
+			if !t.isStopped && t.at <= end && (next == nil || t.at < next.at) {
+				next = t
+			}
+		}
+		if next == nil {
+			break
+		}
+
+		next.isStopped = true
+		c.now = next.at
+		c.mutex.Unlock()
+		next.fn()
+		c.mutex.Lock()
+	}
+
+	c.now = end
+	c.mutex.Unlock()
+}
+
+func (t *testTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+
+	wasActive := !t.isStopped
+	t.isStopped = true
+	return wasActive
+}
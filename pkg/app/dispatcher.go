@@ -2,7 +2,9 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"net/url"
+	"time"
 )
 
 const (
@@ -30,6 +32,20 @@ type Dispatcher interface {
 	// registered with Handle() and Context.Handle().
 	Post(a Action)
 
+	// Posts the given action to its handler and calls the given function with
+	// the reply. An error is replied when the action has no handler or more
+	// than one, or when the given context is done before the handler replies.
+	// The function is called on the goroutine where the reply occurs.
+	Request(ctx context.Context, a Action, onReply func(reply any, err error))
+
+	// Calls the server handler of the given action and returns the JSON encoded
+	// value it returned. The call is canceled when the given context is done.
+	CallServer(ctx context.Context, a Action) (json.RawMessage, error)
+
+	// Registers the given action middlewares that are executed before the
+	// handlers of the actions posted with the dispatcher.
+	UseActionMiddleware(m ...ActionMiddleware)
+
 	// Sets the state with the given value.
 	SetState(state string, v any, opts ...StateOption)
 
@@ -40,10 +56,44 @@ type Dispatcher interface {
 	// Deletes the given state.
 	DelState(state string)
 
+	// Declares a state that is computed with the given function from the
+	// given source states.
+	DeriveState(state string, fn func(Context) any, sources ...string)
+
 	// Creates an observer that observes changes for the specified state while
 	// the given element is mounted.
 	ObserveState(state string, elem UI) Observer
 
+	// Creates an observer that observes changes for the states that match the
+	// given pattern while the given element is mounted.
+	ObserveStates(pattern string, elem UI) PatternObserver
+
+	// Returns the names of the states that match the given pattern.
+	ListStates(pattern string) []string
+
+	// Deletes the states that match the given pattern.
+	DelStates(pattern string)
+
+	// Applies the state changes made in the given function at once. No change
+	// is applied when the function returns an error.
+	BatchStates(fn func(StateBatch) error) error
+
+	// Starts recording state changes in a history bounded to the given size.
+	// States that are encrypted or that match one of the exclude patterns are
+	// not recorded.
+	EnableStateHistory(size int, exclude ...string)
+
+	// Returns the recorded state changes, from the oldest to the most recent.
+	StateHistory() []StateHistoryEntry
+
+	// Reverts the most recent change of a state that matches the given
+	// pattern.
+	UndoState(pattern string) bool
+
+	// Reapplies the most recent undone change of a state that matches the
+	// given pattern.
+	RedoState(pattern string) bool
+
 	// 	Async launches the given function on a new goroutine.
 	//
 	// The difference versus just launching a goroutine is that it ensures that
@@ -53,13 +103,24 @@ type Dispatcher interface {
 	// operations need to complete before sending a pre-rendered page over HTTP.
 	Async(fn func())
 
+	// Dispatches the given function once the given duration elapsed without
+	// another call with the same key for the component of the given element.
+	Debounce(src UI, key string, d time.Duration, fn func(Context))
+
+	// Dispatches the given function, then at most once per given duration for
+	// the calls with the same key for the component of the given element.
+	Throttle(src UI, key string, d time.Duration, fn func(Context))
+
 	// Wait waits for the asynchronous operations launched with Async() to
 	// complete.
 	Wait()
 
 	start(context.Context)
 	getCurrentPage() Page
+	getRouter() *Router
 	getLocalStorage() BrowserStorage
+	getStateStorage() StateStorage
+	getStateVersions() map[string]stateVersion
 	getSessionStorage() BrowserStorage
 	isServerSide() bool
 	resolveStaticResource(string) string
@@ -101,9 +162,7 @@ type ClientDispatcher interface {
 // NewClientTester creates a testing dispatcher that simulates a
 // client environment. The given UI element is mounted upon creation.
 func NewClientTester(n UI) ClientDispatcher {
-	e := &engine{
-		ActionHandlers: actionHandlers,
-	}
+	e := &engine{}
 	e.init()
 	e.Mount(n)
 	e.Consume()
@@ -129,8 +188,7 @@ type ServerDispatcher interface {
 // client environment.
 func NewServerTester(n UI) ServerDispatcher {
 	e := &engine{
-		IsServerSide:   true,
-		ActionHandlers: actionHandlers,
+		IsServerSide: true,
 	}
 	e.init()
 	e.Mount(n)
@@ -1,7 +1,10 @@
 package app
 
 import (
+	"strconv"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestElemMountDismount(t *testing.T) {
@@ -164,3 +167,114 @@ func TestElemUpdate(t *testing.T) {
 		},
 	})
 }
+
+func TestElemUpdateWithKeys(t *testing.T) {
+	newList := func(keys ...string) HTMLUl {
+		return Ul().Body(
+			Range(keys).Slice(func(i int) UI {
+				return Li().
+					Key(keys[i]).
+					Text(keys[i])
+			}),
+		)
+	}
+
+	a := newList("a", "b", "c")
+	d := NewClientTester(a)
+	defer d.Close()
+
+	children := append([]UI{}, a.getChildren()...)
+	require.NoError(t, update(a, newList("c", "d", "a")))
+
+	res := a.getChildren()
+	require.Len(t, res, 3)
+	require.True(t, children[2] == res[0])
+	require.True(t, children[0] == res[2])
+	require.False(t, children[1].Mounted())
+	require.True(t, res[1].Mounted())
+	require.Equal(t, "d", res[1].getKey())
+
+	for _, c := range res {
+		require.Equal(t, a, c.getParent())
+	}
+}
+
+func TestElemUpdateWithKeyedComponents(t *testing.T) {
+	newList := func(keys ...string) HTMLUl {
+		return Ul().Body(
+			Range(keys).Slice(func(i int) UI {
+				return &keyedCompo{ID: keys[i]}
+			}),
+		)
+	}
+
+	a := newList("a", "b", "c")
+	d := NewClientTester(a)
+	defer d.Close()
+
+	children := append([]UI{}, a.getChildren()...)
+	require.NoError(t, update(a, newList("b", "c")))
+
+	res := a.getChildren()
+	require.Len(t, res, 2)
+	require.True(t, children[1] == res[0])
+	require.True(t, children[2] == res[1])
+	require.True(t, res[0].Mounted())
+	require.True(t, res[1].Mounted())
+	require.False(t, children[0].Mounted())
+}
+
+func TestElemUpdateWithReorderedKeyedComponents(t *testing.T) {
+	keys := make([]string, 300)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	newList := func(keys []string) HTMLUl {
+		return Ul().Body(
+			Range(keys).Slice(func(i int) UI {
+				return &keyedCompo{ID: keys[i]}
+			}),
+		)
+	}
+
+	a := newList(keys)
+	d := NewClientTester(a)
+	defer d.Close()
+	children := append([]UI{}, a.getChildren()...)
+
+	reversed := make([]string, len(keys))
+	for i, k := range keys {
+		reversed[len(keys)-1-i] = k
+	}
+	require.NoError(t, update(a, newList(reversed)))
+
+	res := a.getChildren()
+	require.Len(t, res, len(keys))
+	for i, c := range res {
+		require.True(t, children[len(children)-1-i] == c)
+		require.True(t, c.Mounted())
+	}
+}
+
+func TestKeyOf(t *testing.T) {
+	c := &keyedCompo{ID: "a"}
+	require.Equal(t, "a", keyOf(c))
+	require.Nil(t, c.self())
+
+	require.Equal(t, "b", keyOf(Li().Key("b")))
+	require.Empty(t, keyOf(Li()))
+}
+
+type keyedCompo struct {
+	Compo
+
+	ID string
+}
+
+func (c *keyedCompo) Key() string {
+	return c.ID
+}
+
+func (c *keyedCompo) Render() UI {
+	return Li().Text(c.ID)
+}
@@ -1,10 +1,16 @@
 package app
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"net/url"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/maxence-charriere/go-app/v9/pkg/errors"
@@ -26,20 +32,55 @@ type engine struct {
 	// The storage used as session storage.
 	SessionStorage BrowserStorage
 
+	// The storage where persistent states are saved.
+	//
+	// Default: LocalStorage.
+	StateStorage StateStorage
+
+	// The versions of the persistent states, by state name.
+	//
+	// Default: the versions set with VersionState.
+	StateVersions map[string]stateVersion
+
+	// The URL of the server endpoint that executes the server action handlers.
+	ServerActionsURL string
+
+	// The URL of the server endpoint that synchronizes the states set with the
+	// Sync option. States are not synchronized when empty.
+	StateSyncURL string
+
 	// The function used to resolve static resource paths.
 	StaticResourceResolver func(string) string
 
 	// The body of the page.
 	Body HTMLBody
 
+	// Reports whether the first mounted element hydrates the pre-rendered
+	// markup rather than replacing it.
+	Hydrate bool
+
+	// The router that resolves the pages to display.
+	//
+	// Default: the default router.
+	Router *Router
+
 	// The action handlers that are not associated with a component and are
 	// executed asynchronously.
+	//
+	// Default: the router action handlers.
 	ActionHandlers map[string]ActionHandler
 
+	// The action middlewares that are executed before action handlers.
+	//
+	// Default: the router action middlewares.
+	ActionMiddlewares []ActionMiddleware
+
 	initOnce             sync.Once
 	startOnce            sync.Once
 	closeOnce            sync.Once
 	wait                 sync.WaitGroup
+	asyncs               int32
+	asyncDone            chan struct{}
 	componentUpdateMutex sync.RWMutex
 
 	dispatches           chan Dispatch
@@ -47,6 +88,7 @@ type engine struct {
 	componentUpdateQueue []componentUpdate
 	deferables           []Dispatch
 	actions              actionManager
+	limiter              dispatchLimiter
 	states               *store
 	isFirstMount         bool
 }
@@ -92,10 +134,89 @@ func (e *engine) Handle(actionName string, src UI, h ActionHandler) {
 
 func (e *engine) Post(a Action) {
 	e.Async(func() {
-		e.actions.post(a)
+		if a, ok := e.actions.intercept(e.Context(), a); ok {
+			e.actions.post(a)
+		}
+	})
+}
+
+func (e *engine) Request(ctx context.Context, a Action, onReply func(reply any, err error)) {
+	replier := newActionReplier(ctx, a.Name, onReply)
+	a.replier = replier
+
+	e.Async(func() {
+		a, ok := e.actions.intercept(e.Context(), a)
+		if !ok {
+			replier.reply(nil, errors.New("requesting action failed").
+				WithTag("action", a.Name).
+				WithTag("reason", "action rejected by middleware"))
+			return
+		}
+
+		if err := e.actions.request(a); err != nil {
+			replier.reply(nil, err)
+		}
 	})
 }
 
+func (e *engine) CallServer(ctx context.Context, a Action) (json.RawMessage, error) {
+	a, ok := e.actions.intercept(e.Context(), a)
+	if !ok {
+		return nil, errors.New("calling server action failed").
+			WithTag("action", a.Name).
+			WithTag("reason", "action rejected by middleware")
+	}
+
+	if e.ServerActionsURL == "" {
+		return nil, errors.New("calling server action failed").
+			WithTag("action", a.Name).
+			WithTag("reason", "server actions url is not set")
+	}
+
+	body, err := json.Marshal(a)
+	if err != nil {
+		return nil, errors.New("encoding server action failed").
+			WithTag("action", a.Name).
+			Wrap(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.ServerActionsURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.New("creating server action request failed").
+			WithTag("action", a.Name).
+			Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(serverActionHeader, "1")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.New("calling server action failed").
+			WithTag("action", a.Name).
+			Wrap(err)
+	}
+	defer res.Body.Close()
+
+	reply, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, errors.New("reading server action reply failed").
+			WithTag("action", a.Name).
+			Wrap(err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.New("calling server action failed").
+			WithTag("action", a.Name).
+			WithTag("status", res.StatusCode).
+			WithTag("error", strings.TrimSpace(string(reply)))
+	}
+	return reply, nil
+}
+
+func (e *engine) UseActionMiddleware(m ...ActionMiddleware) {
+	e.actions.use(m...)
+}
+
 func (e *engine) SetState(state string, v any, opts ...StateOption) {
 	e.states.Set(state, v, opts...)
 }
@@ -108,18 +229,70 @@ func (e *engine) DelState(state string) {
 	e.states.Del(state)
 }
 
+func (e *engine) DeriveState(state string, fn func(Context) any, sources ...string) {
+	e.states.Derive(state, fn, sources...)
+}
+
 func (e *engine) ObserveState(state string, elem UI) Observer {
 	return e.states.Observe(state, elem)
 }
 
+func (e *engine) ObserveStates(pattern string, elem UI) PatternObserver {
+	return e.states.ObservePattern(pattern, elem)
+}
+
+func (e *engine) ListStates(pattern string) []string {
+	return e.states.List(pattern)
+}
+
+func (e *engine) DelStates(pattern string) {
+	e.states.DelMatching(pattern)
+}
+
+func (e *engine) BatchStates(fn func(StateBatch) error) error {
+	return e.states.Batch(fn)
+}
+
+func (e *engine) EnableStateHistory(size int, exclude ...string) {
+	e.states.EnableHistory(size, exclude...)
+}
+
+func (e *engine) StateHistory() []StateHistoryEntry {
+	return e.states.History()
+}
+
+func (e *engine) UndoState(pattern string) bool {
+	return e.states.Undo(pattern)
+}
+
+func (e *engine) RedoState(pattern string) bool {
+	return e.states.Redo(pattern)
+}
+
 func (e *engine) Async(fn func()) {
 	e.wait.Add(1)
+	atomic.AddInt32(&e.asyncs, 1)
+
 	go func() {
 		fn()
+
+		atomic.AddInt32(&e.asyncs, -1)
+		select {
+		case e.asyncDone <- struct{}{}:
+		default:
+		}
 		e.wait.Done()
 	}()
 }
 
+func (e *engine) Debounce(src UI, key string, d time.Duration, fn func(Context)) {
+	e.limiter.debounce(src, key, d, fn)
+}
+
+func (e *engine) Throttle(src UI, key string, d time.Duration, fn func(Context)) {
+	e.limiter.throttle(src, key, d, fn)
+}
+
 func (e *engine) Wait() {
 	e.wait.Wait()
 }
@@ -139,6 +312,55 @@ func (e *engine) Consume() {
 	}
 }
 
+// consumeQueued executes the queued dispatches without waiting for the
+// goroutines launched with Async.
+func (e *engine) consumeQueued() {
+	for {
+		select {
+		case d := <-e.dispatches:
+			e.handleDispatch(d)
+
+		default:
+			e.handleFrame()
+			return
+		}
+	}
+}
+
+// consumeNext waits for the next dispatch and executes it with the other
+// queued dispatches, without waiting for all the goroutines launched with
+// Async. It returns false when no dispatch is queued and all the Async
+// goroutines are done.
+func (e *engine) consumeNext() bool {
+	for {
+		// Async goroutines dispatch before they are counted as done, so their
+		// dispatches are queued when the count is read as zero.
+		isIdle := atomic.LoadInt32(&e.asyncs) == 0
+
+		select {
+		case d := <-e.dispatches:
+			e.handleDispatch(d)
+			e.consumeQueued()
+			return true
+
+		default:
+		}
+
+		if isIdle {
+			return false
+		}
+
+		select {
+		case d := <-e.dispatches:
+			e.handleDispatch(d)
+			e.consumeQueued()
+			return true
+
+		case <-e.asyncDone:
+		}
+	}
+}
+
 func (e *engine) ConsumeNext() {
 	e.Wait()
 	e.handleDispatch(<-e.dispatches)
@@ -162,7 +384,7 @@ func (e *engine) Mount(v UI) {
 		Source: e.Body,
 		Function: func(ctx Context) {
 			if e.isFirstMount {
-				if err := e.Body.(*htmlBody).replaceChildAt(0, v); err != nil {
+				if err := e.mountFirst(v); err != nil {
 					panic(errors.New("mounting first ui element failed").Wrap(err))
 				}
 
@@ -184,6 +406,44 @@ func (e *engine) Mount(v UI) {
 	})
 }
 
+func (e *engine) mountFirst(v UI) error {
+	body := e.Body.(*htmlBody)
+	if !e.Hydrate {
+		return body.replaceChildAt(0, v)
+	}
+
+	preRender := Window().GetElementByID("app-pre-render")
+	if !preRender.Truthy() {
+		return body.replaceChildAt(0, v)
+	}
+
+	return hydrateBody(e, body, v, preRender)
+}
+
+// hydrateBody hydrates the given element with the markup pre-rendered in the
+// given container and makes it the body content. Pre-rendered nodes are
+// hydrated where they are, then moved into the body with moveBefore, which
+// preserves focus, scroll positions and iframe states in browsers that
+// support it. The previous body content, which contains the container and the
+// loader, is removed.
+func hydrateBody(d Dispatcher, body *htmlBody, v UI, container Value) error {
+	cursor := newDOMCursor(container)
+	if err := hydrate(d, v, cursor); err != nil {
+		return errors.New("hydrating pre-rendered markup failed").Wrap(err)
+	}
+	cursor.removeRemaining()
+
+	old := body.children[0]
+	v.setParent(body)
+	for _, n := range jsNodes(v) {
+		body.JSValue().moveBefore(n, firstJSNode(old))
+	}
+	removeJSNodes(body.JSValue(), old)
+	dismount(old)
+	body.children[0] = v
+	return nil
+}
+
 func (e *engine) Nav(u *url.URL) {
 	if p, ok := e.Page.(*requestPage); ok {
 		p.ReplaceURL(u)
@@ -247,6 +507,26 @@ func (e *engine) init() {
 			e.SessionStorage = newMemoryStorage()
 		}
 
+		if e.StateStorage == nil {
+			e.StateStorage = e.LocalStorage
+		}
+
+		if e.StateVersions == nil {
+			e.StateVersions = defaultStateVersions()
+		}
+
+		if e.Router == nil {
+			e.Router = defaultRouter
+		}
+
+		if e.ActionHandlers == nil {
+			e.ActionHandlers = e.Router.getActionHandlers()
+		}
+
+		if e.ActionMiddlewares == nil {
+			e.ActionMiddlewares = e.Router.getActionMiddlewares()
+		}
+
 		if e.StaticResourceResolver == nil {
 			e.StaticResourceResolver = func(path string) string {
 				return path
@@ -262,18 +542,27 @@ func (e *engine) init() {
 		}
 
 		e.dispatches = make(chan Dispatch, 4096)
+		e.asyncDone = make(chan struct{}, 1)
 		e.componentUpdates = make(map[Composer]bool)
 		e.componentUpdateQueue = make([]componentUpdate, 0, 32)
 		e.deferables = make([]Dispatch, 32)
 		e.states = newStore(e)
+		if e.StateSyncURL != "" {
+			e.states.initSync(e.StateSyncURL, e.Context().DeviceID())
+		}
 		e.isFirstMount = true
 
 		for actionName, handler := range e.ActionHandlers {
 			e.actions.handle(actionName, true, e.Body, handler)
 		}
+		e.actions.use(e.ActionMiddlewares...)
 	})
 }
 
+func (e *engine) getRouter() *Router {
+	return e.Router
+}
+
 func (e *engine) getCurrentPage() Page {
 	return e.Page
 }
@@ -282,6 +571,14 @@ func (e *engine) getLocalStorage() BrowserStorage {
 	return e.LocalStorage
 }
 
+func (e *engine) getStateStorage() StateStorage {
+	return e.StateStorage
+}
+
+func (e *engine) getStateVersions() map[string]stateVersion {
+	return e.StateVersions
+}
+
 func (e *engine) getSessionStorage() BrowserStorage {
 	return e.SessionStorage
 }
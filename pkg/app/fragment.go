@@ -0,0 +1,198 @@
+package app
+
+import (
+	"context"
+	"io"
+	"reflect"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+// Fragment returns a UI element that groups the given elements without
+// wrapping them into an HTML element.
+//
+// Fragment content is inserted directly into the parent element. It is
+// typically used to return multiple root elements from a component Render
+// method:
+//
+//	func (c *row) Render() app.UI {
+//	    return app.Fragment(
+//	        app.Td().Text(c.Name),
+//	        app.Td().Text(c.Email),
+//	    )
+//	}
+func Fragment(elems ...UI) UI {
+	return &fragment{children: FilterUIElems(elems...)}
+}
+
+type fragment struct {
+	children []UI
+
+	disp       Dispatcher
+	ctx        context.Context
+	ctxCancel  func()
+	parentElem UI
+	anchor     Value
+	this       UI
+}
+
+func (f *fragment) Kind() Kind {
+	return FragmentElem
+}
+
+func (f *fragment) JSValue() Value {
+	if len(f.children) != 0 {
+		return f.children[0].JSValue()
+	}
+	return f.anchor
+}
+
+func (f *fragment) Mounted() bool {
+	return f.ctx != nil && f.ctx.Err() == nil
+}
+
+func (f *fragment) name() string {
+	return "fragment"
+}
+
+func (f *fragment) getKey() string {
+	return ""
+}
+
+func (f *fragment) self() UI {
+	return f.this
+}
+
+func (f *fragment) setSelf(v UI) {
+	f.this = v
+}
+
+func (f *fragment) getContext() context.Context {
+	return f.ctx
+}
+
+func (f *fragment) getDispatcher() Dispatcher {
+	return f.disp
+}
+
+func (f *fragment) getAttributes() attributes {
+	return nil
+}
+
+func (f *fragment) getEventHandlers() eventHandlers {
+	return nil
+}
+
+func (f *fragment) getParent() UI {
+	return f.parentElem
+}
+
+func (f *fragment) setParent(p UI) {
+	f.parentElem = p
+}
+
+func (f *fragment) getChildren() []UI {
+	return f.children
+}
+
+func (f *fragment) mount(d Dispatcher) error {
+	if f.Mounted() {
+		return errors.New("mounting fragment failed").
+			WithTag("reason", "already mounted").
+			WithTag("name", f.name()).
+			WithTag("kind", f.Kind())
+	}
+
+	f.ctx, f.ctxCancel = context.WithCancel(context.Background())
+	f.disp = d
+
+	// The anchor is an empty text node that marks the end of the fragment. It
+	// is where new children are inserted when the fragment is updated.
+	f.anchor = Window().createTextNode("")
+
+	for i, c := range f.children {
+		if err := mount(d, c); err != nil {
+			return errors.New("mounting child failed").
+				WithTag("index", i).
+				WithTag("child", c.name()).
+				WithTag("child-kind", c.Kind()).
+				Wrap(err)
+		}
+		c.setParent(f.self())
+	}
+
+	return nil
+}
+
+func (f *fragment) hydrate(d Dispatcher, cursor *domCursor) error {
+	f.ctx, f.ctxCancel = context.WithCancel(context.Background())
+	f.disp = d
+
+	for i, c := range f.children {
+		if err := hydrate(d, c, cursor); err != nil {
+			return errors.New("hydrating child failed").
+				WithTag("index", i).
+				WithTag("child", c.name()).
+				WithTag("child-kind", c.Kind()).
+				Wrap(err)
+		}
+		c.setParent(f.self())
+	}
+
+	f.anchor = Window().createTextNode("")
+	cursor.insertNode(f.anchor)
+	return nil
+}
+
+func (f *fragment) dismount() {
+	for _, c := range f.children {
+		dismount(c)
+	}
+	f.ctxCancel()
+}
+
+func (f *fragment) canUpdateWith(v UI) bool {
+	return f.Mounted() && f.Kind() == v.Kind()
+}
+
+func (f *fragment) updateWith(v UI) error {
+	if !f.canUpdateWith(v) {
+		return errors.New("cannot update fragment with given element").
+			WithTag("current", reflect.TypeOf(f.self())).
+			WithTag("new", reflect.TypeOf(v))
+	}
+
+	children, err := updateChildren(f.self(), getJSParent(f), f.anchor, f.children, v.getChildren())
+	f.children = children
+	return err
+}
+
+func (f *fragment) onComponentEvent(le any) {
+	for _, c := range f.children {
+		c.onComponentEvent(le)
+	}
+}
+
+func (f *fragment) html(w io.Writer) {
+	for i, c := range f.children {
+		if i > 0 {
+			io.WriteString(w, "\n")
+		}
+		if c.self() == nil {
+			c.setSelf(c)
+		}
+		c.html(w)
+	}
+}
+
+func (f *fragment) htmlWithIndent(w io.Writer, indent int) {
+	for i, c := range f.children {
+		if i > 0 {
+			io.WriteString(w, "\n")
+		}
+		if c.self() == nil {
+			c.setSelf(c)
+		}
+		c.htmlWithIndent(w, indent)
+	}
+}
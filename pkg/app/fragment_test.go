@@ -0,0 +1,205 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFragmentMountDismount(t *testing.T) {
+	testMountDismount(t, []mountTest{
+		{
+			scenario: "component with multiple roots",
+			node: Ul().Body(
+				&multiRootCompo{Items: []string{"a", "b"}},
+			),
+		},
+		{
+			scenario: "component without root",
+			node: Ul().Body(
+				&multiRootCompo{},
+			),
+		},
+	})
+}
+
+func TestFragmentUpdate(t *testing.T) {
+	testUpdate(t, []updateTest{
+		{
+			scenario: "fragment child is appended",
+			a: Ul().Body(
+				&multiRootCompo{Items: []string{"a", "b"}},
+			),
+			b: Ul().Body(
+				&multiRootCompo{Items: []string{"a", "b", "c"}},
+			),
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0),
+					Expected: &multiRootCompo{Items: []string{"a", "b", "c"}},
+				},
+				{
+					Path:     TestPath(0, 0),
+					Expected: Fragment(Li(), Li(), Li()),
+				},
+				{
+					Path:     TestPath(0, 0, 2),
+					Expected: Li(),
+				},
+				{
+					Path:     TestPath(0, 0, 2, 0),
+					Expected: Text("c"),
+				},
+			},
+		},
+		{
+			scenario: "fragment child is removed",
+			a: Ul().Body(
+				&multiRootCompo{Items: []string{"a", "b", "c"}},
+			),
+			b: Ul().Body(
+				&multiRootCompo{Items: []string{"a", "c"}},
+			),
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0, 0, 1, 0),
+					Expected: Text("c"),
+				},
+				{
+					Path:     TestPath(0, 0, 2),
+					Expected: nil,
+				},
+			},
+		},
+		{
+			scenario: "fragment is replaced by a single root",
+			a: Ul().Body(
+				&multiRootCompo{Items: []string{"a", "b"}},
+			),
+			b: Ul().Body(
+				&multiRootCompo{Items: []string{"b"}},
+			),
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0, 0),
+					Expected: Li(),
+				},
+				{
+					Path:     TestPath(0, 0, 0),
+					Expected: Text("b"),
+				},
+			},
+		},
+		{
+			scenario: "single root is replaced by an empty fragment",
+			a: Ul().Body(
+				&multiRootCompo{Items: []string{"a"}},
+			),
+			b: Ul().Body(
+				&multiRootCompo{},
+			),
+			matches: []TestUIDescriptor{
+				{
+					Path:     TestPath(0, 0),
+					Expected: Fragment(),
+				},
+			},
+		},
+	})
+}
+
+func TestFragmentHTML(t *testing.T) {
+	expected := Ul().Body(
+		Li().Text("a"),
+		Li().Text("b"),
+	)
+
+	compo := Ul().Body(
+		&multiRootCompo{Items: []string{"a", "b"}},
+	)
+	require.Equal(t, HTMLString(expected), HTMLString(compo))
+	require.Equal(t, HTMLStringWithIndent(expected), HTMLStringWithIndent(compo))
+
+	fragment := Ul().Body(
+		Fragment(
+			Li().Text("a"),
+			Li().Text("b"),
+		),
+	)
+	require.Equal(t, HTMLString(expected), HTMLString(fragment))
+}
+
+func TestFragmentMatch(t *testing.T) {
+	root := Ul().Body(
+		&multiRootCompo{Items: []string{"a", "b"}},
+	)
+	d := NewClientTester(root)
+	defer d.Close()
+	d.Consume()
+
+	utests := []struct {
+		scenario string
+		expected UI
+		err      bool
+	}{
+		{
+			scenario: "fragment with matching children",
+			expected: Fragment(Li(), Li()),
+		},
+		{
+			scenario: "fragment with less children",
+			expected: Fragment(Li()),
+			err:      true,
+		},
+		{
+			scenario: "empty fragment",
+			expected: Fragment(),
+			err:      true,
+		},
+		{
+			scenario: "fragment with a child of another kind",
+			expected: Fragment(Li(), Text("b")),
+			err:      true,
+		},
+		{
+			scenario: "fragment with a child with other attributes",
+			expected: Fragment(Li(), Li().Class("b")),
+			err:      true,
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			err := TestMatch(root, TestUIDescriptor{
+				Path:     TestPath(0, 0),
+				Expected: u.expected,
+			})
+			if u.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFilterUIElemsWithFragment(t *testing.T) {
+	a := Text("a")
+	b := Text("b")
+	c := Text("c")
+
+	res := FilterUIElems(a, Fragment(b, Fragment(c)))
+	require.Equal(t, []UI{a, b, c}, res)
+}
+
+type multiRootCompo struct {
+	Compo
+
+	Items []string
+}
+
+func (c *multiRootCompo) Render() UI {
+	return Range(c.Items).Slice(func(i int) UI {
+		return Li().Text(c.Items[i])
+	})
+}
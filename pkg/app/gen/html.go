@@ -3175,6 +3175,11 @@ var attrs = map[string]attr{
 	},
 
 	// K:
+	"key": {
+		Name: "Key",
+		Type: "key",
+		Doc:  "specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.",
+	},
 	"kind": {
 		Name: "Kind",
 		Type: "string",
@@ -4611,6 +4616,7 @@ func withGlobalAttrs(attrs ...attr) []attr {
 		"draggable",
 		"hidden",
 		"id",
+		"key",
 		"lang",
 		"role",
 		"spellcheck",
@@ -5740,6 +5746,15 @@ func writeAttrFunction(w io.Writer, a attr, t tag, isInterface bool) {
 			}`, attrName)
 		}
 
+	case "key":
+		fmt.Fprintf(w, `%s(v string) HTML%s`, a.Name, t.Name)
+		if !isInterface {
+			fmt.Fprintf(w, `{
+				e.key = v
+				return e
+			}`)
+		}
+
 	case "xmlns":
 		fmt.Fprintf(w, `%s(v string) HTML%s`, a.Name, t.Name)
 		if !isInterface {
@@ -5826,7 +5841,7 @@ import (
 			case "int":
 				fmt.Fprintln(f, `42)`)
 
-			case "string":
+			case "string", "key":
 				fmt.Fprintln(f, `"foo")`)
 
 			case "url":
@@ -11,6 +11,7 @@ import (
 type htmlElement struct {
 	tag           string
 	xmlns         string
+	key           string
 	isSelfClosing bool
 	attributes    attributes
 	eventHandlers eventHandlers
@@ -40,6 +41,10 @@ func (e *htmlElement) name() string {
 	return e.tag
 }
 
+func (e *htmlElement) getKey() string {
+	return e.key
+}
+
 func (e *htmlElement) self() UI {
 	return e.this
 }
@@ -109,12 +114,41 @@ func (e *htmlElement) mount(d Dispatcher) error {
 		}
 
 		c.setParent(e.self())
-		e.JSValue().appendChild(c)
+		insertJSNodes(e.JSValue(), c, nil)
 	}
 
 	return nil
 }
 
+func (e *htmlElement) hydrate(d Dispatcher, cursor *domCursor) error {
+	cursor.skipFormatting()
+	if !cursor.isElement(e.tag) {
+		return remount(d, e.self(), cursor)
+	}
+
+	e.context, e.contextCancel = context.WithCancel(context.Background())
+	e.dispatcher = d
+	e.jsElement = cursor.next()
+
+	e.attributes.Mount(e.jsElement, d.resolveStaticResource)
+	e.eventHandlers.Mount(e)
+
+	children := newDOMCursor(e.jsElement)
+	for i, c := range e.children {
+		if err := hydrate(d, c, children); err != nil {
+			return errors.New("hydrating child failed").
+				WithTag("index", i).
+				WithTag("child", c.name()).
+				WithTag("child-kind", c.Kind()).
+				Wrap(err)
+		}
+		c.setParent(e.self())
+	}
+	children.removeRemaining()
+
+	return nil
+}
+
 func (e *htmlElement) dismount() {
 	for _, c := range e.children {
 		dismount(c)
@@ -158,68 +192,15 @@ func (e *htmlElement) updateWith(v UI) error {
 		e.eventHandlers.Update(e, v.getEventHandlers())
 	}
 
-	childrenA := e.children
-	childrenB := v.getChildren()
-	i := 0
-
-	for len(childrenA) != 0 && len(childrenB) != 0 {
-		a := childrenA[0]
-		b := childrenB[0]
-
-		if canUpdate(a, b) {
-			if err := update(a, b); err != nil {
-				return errors.New("updating child failed").
-					WithTag("child", reflect.TypeOf(a)).
-					WithTag("new-child", reflect.TypeOf(b)).
-					WithTag("index", i).
-					Wrap(err)
-			}
-		} else {
-			if err := e.replaceChildAt(i, b); err != nil {
-				return errors.New("replacing child failed").
-					WithTag("child", reflect.TypeOf(a)).
-					WithTag("new-child", reflect.TypeOf(b)).
-					WithTag("index", i).
-					Wrap(err)
-			}
-		}
-
-		childrenA = childrenA[1:]
-		childrenB = childrenB[1:]
-		i++
-	}
-
-	for len(childrenA) != 0 {
-		if err := e.removeChildAt(i); err != nil {
-			return errors.New("removing child failed").
-				WithTag("child", reflect.TypeOf(childrenA[0])).
-				WithTag("index", i).
-				Wrap(err)
-		}
-
-		childrenA = childrenA[1:]
-	}
-
-	for len(childrenB) != 0 {
-		b := childrenB[0]
-
-		if err := e.appendChild(b); err != nil {
-			return errors.New("appending child failed").
-				WithTag("child", reflect.TypeOf(b)).
-				WithTag("index", i).
-				Wrap(err)
-		}
-
-		childrenB = childrenB[1:]
-	}
-
-	return nil
+	children, err := updateChildren(e.self(), e.JSValue(), nil, e.children, v.getChildren())
+	e.children = children
+	return err
 }
 
 func (e *htmlElement) replaceChildAt(idx int, new UI) error {
 	old := e.children[idx]
 
-	if err := mount(e.getDispatcher(), new); err != nil {
+	if err := replace(e.self(), e.JSValue(), old, new); err != nil {
 		return errors.New("replacing child failed").
 			WithTag("name", e.name()).
 			WithTag("kind", e.Kind()).
@@ -232,41 +213,6 @@ func (e *htmlElement) replaceChildAt(idx int, new UI) error {
 	}
 
 	e.children[idx] = new
-	new.setParent(e.self())
-	e.JSValue().replaceChild(new, old)
-
-	dismount(old)
-	return nil
-}
-
-func (e *htmlElement) removeChildAt(i int) error {
-	if i < 0 || i >= len(e.children) {
-		return errors.New("index out of range").
-			WithTag("index", i).
-			WithTag("children-count", len(e.children))
-	}
-
-	child := e.children[i]
-	e.jsElement.removeChild(child)
-	dismount(child)
-
-	children := e.children
-	copy(children[i:], children[i+1:])
-	children[len(children)-1] = nil
-	e.children = children[:len(children)-1]
-	return nil
-}
-
-func (e *htmlElement) appendChild(v UI) error {
-	if err := mount(e.getDispatcher(), v); err != nil {
-		return errors.New("mounting element failed").
-			WithTag("element", reflect.TypeOf(v)).
-			Wrap(err)
-	}
-
-	v.setParent(e.self())
-	e.JSValue().appendChild(v)
-	e.children = append(e.children, v)
 	return nil
 }
 
@@ -301,26 +247,30 @@ func (e *htmlElement) onComponentEvent(le any) {
 }
 
 func (e *htmlElement) html(w io.Writer) {
-	io.WriteString(w, "<")
-	io.WriteString(w, e.tag)
+	e.htmlOpeningTag(w)
 
-	for k, v := range e.attributes {
-		io.WriteString(w, " ")
-		io.WriteString(w, k)
+	if e.isSelfClosing {
+		return
+	}
 
-		if v != "" {
-			io.WriteString(w, `="`)
-			io.WriteString(w, resolveAttributeURLValue(k, v, func(s string) string {
-				if e.dispatcher != nil {
-					return e.dispatcher.resolveStaticResource(v)
-				}
-				return v
-			}))
-			io.WriteString(w, `"`)
+	for _, c := range e.children {
+		io.WriteString(w, "\n")
+		if c.self() == nil {
+			c.setSelf(c)
 		}
+		c.html(w)
 	}
 
-	io.WriteString(w, ">")
+	if len(e.children) != 0 {
+		io.WriteString(w, "\n")
+	}
+
+	e.htmlClosingTag(w)
+}
+
+func (e *htmlElement) htmlWithIndent(w io.Writer, indent int) {
+	writeIndent(w, indent)
+	e.htmlOpeningTag(w)
 
 	if e.isSelfClosing {
 		return
@@ -331,24 +281,23 @@ func (e *htmlElement) html(w io.Writer) {
 		if c.self() == nil {
 			c.setSelf(c)
 		}
-		c.html(w)
+		c.htmlWithIndent(w, indent+1)
 	}
 
 	if len(e.children) != 0 {
 		io.WriteString(w, "\n")
+		writeIndent(w, indent)
 	}
 
-	io.WriteString(w, "</")
-	io.WriteString(w, e.tag)
-	io.WriteString(w, ">")
+	e.htmlClosingTag(w)
 }
 
-func (e *htmlElement) htmlWithIndent(w io.Writer, indent int) {
-	writeIndent(w, indent)
+func (e *htmlElement) htmlOpeningTag(w io.Writer) {
 	io.WriteString(w, "<")
 	io.WriteString(w, e.tag)
 
-	for k, v := range e.attributes {
+	for _, k := range e.attributes.names() {
+		v := e.attributes[k]
 		io.WriteString(w, " ")
 		io.WriteString(w, k)
 
@@ -365,24 +314,9 @@ func (e *htmlElement) htmlWithIndent(w io.Writer, indent int) {
 	}
 
 	io.WriteString(w, ">")
+}
 
-	if e.isSelfClosing {
-		return
-	}
-
-	for _, c := range e.children {
-		io.WriteString(w, "\n")
-		if c.self() == nil {
-			c.setSelf(c)
-		}
-		c.htmlWithIndent(w, indent+1)
-	}
-
-	if len(e.children) != 0 {
-		io.WriteString(w, "\n")
-		writeIndent(w, indent)
-	}
-
+func (e *htmlElement) htmlClosingTag(w io.Writer) {
 	io.WriteString(w, "</")
 	io.WriteString(w, e.tag)
 	io.WriteString(w, ">")
@@ -69,6 +69,10 @@ type HTMLA interface {
 	 */
 	ID(v string) HTMLA
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLA
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLA
@@ -347,6 +351,11 @@ func (e *htmlA) ID(v string) HTMLA {
 	return e
 }
 
+func (e *htmlA) Key(v string) HTMLA {
+	e.key = v
+	return e
+}
+
 func (e *htmlA) Lang(v string) HTMLA {
 	e.setAttr("lang", v)
 	return e
@@ -639,6 +648,10 @@ type HTMLAbbr interface {
 	 */
 	ID(v string) HTMLAbbr
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLAbbr
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLAbbr
@@ -882,6 +895,11 @@ func (e *htmlAbbr) ID(v string) HTMLAbbr {
 	return e
 }
 
+func (e *htmlAbbr) Key(v string) HTMLAbbr {
+	e.key = v
+	return e
+}
+
 func (e *htmlAbbr) Lang(v string) HTMLAbbr {
 	e.setAttr("lang", v)
 	return e
@@ -1149,6 +1167,10 @@ type HTMLAddress interface {
 	 */
 	ID(v string) HTMLAddress
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLAddress
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLAddress
@@ -1392,6 +1414,11 @@ func (e *htmlAddress) ID(v string) HTMLAddress {
 	return e
 }
 
+func (e *htmlAddress) Key(v string) HTMLAddress {
+	e.key = v
+	return e
+}
+
 func (e *htmlAddress) Lang(v string) HTMLAddress {
 	e.setAttr("lang", v)
 	return e
@@ -1673,6 +1700,10 @@ type HTMLArea interface {
 	 */
 	ID(v string) HTMLArea
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLArea
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLArea
@@ -1952,6 +1983,11 @@ func (e *htmlArea) ID(v string) HTMLArea {
 	return e
 }
 
+func (e *htmlArea) Key(v string) HTMLArea {
+	e.key = v
+	return e
+}
+
 func (e *htmlArea) Lang(v string) HTMLArea {
 	e.setAttr("lang", v)
 	return e
@@ -2244,6 +2280,10 @@ type HTMLArticle interface {
 	 */
 	ID(v string) HTMLArticle
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLArticle
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLArticle
@@ -2487,6 +2527,11 @@ func (e *htmlArticle) ID(v string) HTMLArticle {
 	return e
 }
 
+func (e *htmlArticle) Key(v string) HTMLArticle {
+	e.key = v
+	return e
+}
+
 func (e *htmlArticle) Lang(v string) HTMLArticle {
 	e.setAttr("lang", v)
 	return e
@@ -2754,6 +2799,10 @@ type HTMLAside interface {
 	 */
 	ID(v string) HTMLAside
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLAside
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLAside
@@ -2997,6 +3046,11 @@ func (e *htmlAside) ID(v string) HTMLAside {
 	return e
 }
 
+func (e *htmlAside) Key(v string) HTMLAside {
+	e.key = v
+	return e
+}
+
 func (e *htmlAside) Lang(v string) HTMLAside {
 	e.setAttr("lang", v)
 	return e
@@ -3276,6 +3330,10 @@ type HTMLAudio interface {
 	 */
 	ID(v string) HTMLAudio
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLAudio
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLAudio
@@ -3642,6 +3700,11 @@ func (e *htmlAudio) ID(v string) HTMLAudio {
 	return e
 }
 
+func (e *htmlAudio) Key(v string) HTMLAudio {
+	e.key = v
+	return e
+}
+
 func (e *htmlAudio) Lang(v string) HTMLAudio {
 	e.setAttr("lang", v)
 	return e
@@ -4044,6 +4107,10 @@ type HTMLB interface {
 	 */
 	ID(v string) HTMLB
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLB
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLB
@@ -4287,6 +4354,11 @@ func (e *htmlB) ID(v string) HTMLB {
 	return e
 }
 
+func (e *htmlB) Key(v string) HTMLB {
+	e.key = v
+	return e
+}
+
 func (e *htmlB) Lang(v string) HTMLB {
 	e.setAttr("lang", v)
 	return e
@@ -4552,6 +4624,10 @@ type HTMLBase interface {
 	 */
 	ID(v string) HTMLBase
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLBase
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLBase
@@ -4795,6 +4871,11 @@ func (e *htmlBase) ID(v string) HTMLBase {
 	return e
 }
 
+func (e *htmlBase) Key(v string) HTMLBase {
+	e.key = v
+	return e
+}
+
 func (e *htmlBase) Lang(v string) HTMLBase {
 	e.setAttr("lang", v)
 	return e
@@ -5067,6 +5148,10 @@ type HTMLBdi interface {
 	 */
 	ID(v string) HTMLBdi
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLBdi
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLBdi
@@ -5310,6 +5395,11 @@ func (e *htmlBdi) ID(v string) HTMLBdi {
 	return e
 }
 
+func (e *htmlBdi) Key(v string) HTMLBdi {
+	e.key = v
+	return e
+}
+
 func (e *htmlBdi) Lang(v string) HTMLBdi {
 	e.setAttr("lang", v)
 	return e
@@ -5577,6 +5667,10 @@ type HTMLBdo interface {
 	 */
 	ID(v string) HTMLBdo
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLBdo
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLBdo
@@ -5820,6 +5914,11 @@ func (e *htmlBdo) ID(v string) HTMLBdo {
 	return e
 }
 
+func (e *htmlBdo) Key(v string) HTMLBdo {
+	e.key = v
+	return e
+}
+
 func (e *htmlBdo) Lang(v string) HTMLBdo {
 	e.setAttr("lang", v)
 	return e
@@ -6091,6 +6190,10 @@ type HTMLBlockquote interface {
 	 */
 	ID(v string) HTMLBlockquote
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLBlockquote
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLBlockquote
@@ -6339,6 +6442,11 @@ func (e *htmlBlockquote) ID(v string) HTMLBlockquote {
 	return e
 }
 
+func (e *htmlBlockquote) Key(v string) HTMLBlockquote {
+	e.key = v
+	return e
+}
+
 func (e *htmlBlockquote) Lang(v string) HTMLBlockquote {
 	e.setAttr("lang", v)
 	return e
@@ -6602,6 +6710,10 @@ type HTMLBody interface {
 	 */
 	ID(v string) HTMLBody
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLBody
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLBody
@@ -6901,6 +7013,11 @@ func (e *htmlBody) ID(v string) HTMLBody {
 	return e
 }
 
+func (e *htmlBody) Key(v string) HTMLBody {
+	e.key = v
+	return e
+}
+
 func (e *htmlBody) Lang(v string) HTMLBody {
 	e.setAttr("lang", v)
 	return e
@@ -7237,6 +7354,10 @@ type HTMLBr interface {
 	 */
 	ID(v string) HTMLBr
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLBr
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLBr
@@ -7471,6 +7592,11 @@ func (e *htmlBr) ID(v string) HTMLBr {
 	return e
 }
 
+func (e *htmlBr) Key(v string) HTMLBr {
+	e.key = v
+	return e
+}
+
 func (e *htmlBr) Lang(v string) HTMLBr {
 	e.setAttr("lang", v)
 	return e
@@ -7770,6 +7896,10 @@ type HTMLButton interface {
 	 */
 	ID(v string) HTMLButton
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLButton
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLButton
@@ -8065,6 +8195,11 @@ func (e *htmlButton) ID(v string) HTMLButton {
 	return e
 }
 
+func (e *htmlButton) Key(v string) HTMLButton {
+	e.key = v
+	return e
+}
+
 func (e *htmlButton) Lang(v string) HTMLButton {
 	e.setAttr("lang", v)
 	return e
@@ -8351,6 +8486,10 @@ type HTMLCanvas interface {
 	 */
 	ID(v string) HTMLCanvas
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLCanvas
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLCanvas
@@ -8603,6 +8742,11 @@ func (e *htmlCanvas) ID(v string) HTMLCanvas {
 	return e
 }
 
+func (e *htmlCanvas) Key(v string) HTMLCanvas {
+	e.key = v
+	return e
+}
+
 func (e *htmlCanvas) Lang(v string) HTMLCanvas {
 	e.setAttr("lang", v)
 	return e
@@ -8875,6 +9019,10 @@ type HTMLCaption interface {
 	 */
 	ID(v string) HTMLCaption
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLCaption
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLCaption
@@ -9118,6 +9266,11 @@ func (e *htmlCaption) ID(v string) HTMLCaption {
 	return e
 }
 
+func (e *htmlCaption) Key(v string) HTMLCaption {
+	e.key = v
+	return e
+}
+
 func (e *htmlCaption) Lang(v string) HTMLCaption {
 	e.setAttr("lang", v)
 	return e
@@ -9385,6 +9538,10 @@ type HTMLCite interface {
 	 */
 	ID(v string) HTMLCite
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLCite
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLCite
@@ -9628,6 +9785,11 @@ func (e *htmlCite) ID(v string) HTMLCite {
 	return e
 }
 
+func (e *htmlCite) Key(v string) HTMLCite {
+	e.key = v
+	return e
+}
+
 func (e *htmlCite) Lang(v string) HTMLCite {
 	e.setAttr("lang", v)
 	return e
@@ -9895,6 +10057,10 @@ type HTMLCode interface {
 	 */
 	ID(v string) HTMLCode
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLCode
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLCode
@@ -10138,6 +10304,11 @@ func (e *htmlCode) ID(v string) HTMLCode {
 	return e
 }
 
+func (e *htmlCode) Key(v string) HTMLCode {
+	e.key = v
+	return e
+}
+
 func (e *htmlCode) Lang(v string) HTMLCode {
 	e.setAttr("lang", v)
 	return e
@@ -10399,6 +10570,10 @@ type HTMLCol interface {
 	 */
 	ID(v string) HTMLCol
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLCol
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLCol
@@ -10637,6 +10812,11 @@ func (e *htmlCol) ID(v string) HTMLCol {
 	return e
 }
 
+func (e *htmlCol) Key(v string) HTMLCol {
+	e.key = v
+	return e
+}
+
 func (e *htmlCol) Lang(v string) HTMLCol {
 	e.setAttr("lang", v)
 	return e
@@ -10909,6 +11089,10 @@ type HTMLColGroup interface {
 	 */
 	ID(v string) HTMLColGroup
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLColGroup
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLColGroup
@@ -11156,6 +11340,11 @@ func (e *htmlColGroup) ID(v string) HTMLColGroup {
 	return e
 }
 
+func (e *htmlColGroup) Key(v string) HTMLColGroup {
+	e.key = v
+	return e
+}
+
 func (e *htmlColGroup) Lang(v string) HTMLColGroup {
 	e.setAttr("lang", v)
 	return e
@@ -11428,6 +11617,10 @@ type HTMLData interface {
 	 */
 	ID(v string) HTMLData
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLData
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLData
@@ -11539,6 +11732,11 @@ func (e *htmlData) ID(v string) HTMLData {
 	return e
 }
 
+func (e *htmlData) Key(v string) HTMLData {
+	e.key = v
+	return e
+}
+
 func (e *htmlData) Lang(v string) HTMLData {
 	e.setAttr("lang", v)
 	return e
@@ -11641,6 +11839,10 @@ type HTMLDataList interface {
 	 */
 	ID(v string) HTMLDataList
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLDataList
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLDataList
@@ -11884,6 +12086,11 @@ func (e *htmlDataList) ID(v string) HTMLDataList {
 	return e
 }
 
+func (e *htmlDataList) Key(v string) HTMLDataList {
+	e.key = v
+	return e
+}
+
 func (e *htmlDataList) Lang(v string) HTMLDataList {
 	e.setAttr("lang", v)
 	return e
@@ -12151,6 +12358,10 @@ type HTMLDd interface {
 	 */
 	ID(v string) HTMLDd
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLDd
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLDd
@@ -12394,6 +12605,11 @@ func (e *htmlDd) ID(v string) HTMLDd {
 	return e
 }
 
+func (e *htmlDd) Key(v string) HTMLDd {
+	e.key = v
+	return e
+}
+
 func (e *htmlDd) Lang(v string) HTMLDd {
 	e.setAttr("lang", v)
 	return e
@@ -12669,6 +12885,10 @@ type HTMLDel interface {
 	 */
 	ID(v string) HTMLDel
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLDel
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLDel
@@ -12922,6 +13142,11 @@ func (e *htmlDel) ID(v string) HTMLDel {
 	return e
 }
 
+func (e *htmlDel) Key(v string) HTMLDel {
+	e.key = v
+	return e
+}
+
 func (e *htmlDel) Lang(v string) HTMLDel {
 	e.setAttr("lang", v)
 	return e
@@ -13189,6 +13414,10 @@ type HTMLDetails interface {
 	 */
 	ID(v string) HTMLDetails
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLDetails
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLDetails
@@ -13440,6 +13669,11 @@ func (e *htmlDetails) ID(v string) HTMLDetails {
 	return e
 }
 
+func (e *htmlDetails) Key(v string) HTMLDetails {
+	e.key = v
+	return e
+}
+
 func (e *htmlDetails) Lang(v string) HTMLDetails {
 	e.setAttr("lang", v)
 	return e
@@ -13717,6 +13951,10 @@ type HTMLDfn interface {
 	 */
 	ID(v string) HTMLDfn
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLDfn
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLDfn
@@ -13960,6 +14198,11 @@ func (e *htmlDfn) ID(v string) HTMLDfn {
 	return e
 }
 
+func (e *htmlDfn) Key(v string) HTMLDfn {
+	e.key = v
+	return e
+}
+
 func (e *htmlDfn) Lang(v string) HTMLDfn {
 	e.setAttr("lang", v)
 	return e
@@ -14227,6 +14470,10 @@ type HTMLDialog interface {
 	 */
 	ID(v string) HTMLDialog
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLDialog
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLDialog
@@ -14474,6 +14721,11 @@ func (e *htmlDialog) ID(v string) HTMLDialog {
 	return e
 }
 
+func (e *htmlDialog) Key(v string) HTMLDialog {
+	e.key = v
+	return e
+}
+
 func (e *htmlDialog) Lang(v string) HTMLDialog {
 	e.setAttr("lang", v)
 	return e
@@ -14746,6 +14998,10 @@ type HTMLDiv interface {
 	 */
 	ID(v string) HTMLDiv
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLDiv
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLDiv
@@ -14989,6 +15245,11 @@ func (e *htmlDiv) ID(v string) HTMLDiv {
 	return e
 }
 
+func (e *htmlDiv) Key(v string) HTMLDiv {
+	e.key = v
+	return e
+}
+
 func (e *htmlDiv) Lang(v string) HTMLDiv {
 	e.setAttr("lang", v)
 	return e
@@ -15256,6 +15517,10 @@ type HTMLDl interface {
 	 */
 	ID(v string) HTMLDl
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLDl
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLDl
@@ -15499,6 +15764,11 @@ func (e *htmlDl) ID(v string) HTMLDl {
 	return e
 }
 
+func (e *htmlDl) Key(v string) HTMLDl {
+	e.key = v
+	return e
+}
+
 func (e *htmlDl) Lang(v string) HTMLDl {
 	e.setAttr("lang", v)
 	return e
@@ -15766,6 +16036,10 @@ type HTMLDt interface {
 	 */
 	ID(v string) HTMLDt
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLDt
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLDt
@@ -16009,6 +16283,11 @@ func (e *htmlDt) ID(v string) HTMLDt {
 	return e
 }
 
+func (e *htmlDt) Key(v string) HTMLDt {
+	e.key = v
+	return e
+}
+
 func (e *htmlDt) Lang(v string) HTMLDt {
 	e.setAttr("lang", v)
 	return e
@@ -16276,6 +16555,10 @@ type HTMLElem interface {
 	 */
 	ID(v string) HTMLElem
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLElem
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLElem
@@ -16523,6 +16806,11 @@ func (e *htmlElem) ID(v string) HTMLElem {
 	return e
 }
 
+func (e *htmlElem) Key(v string) HTMLElem {
+	e.key = v
+	return e
+}
+
 func (e *htmlElem) Lang(v string) HTMLElem {
 	e.setAttr("lang", v)
 	return e
@@ -16789,6 +17077,10 @@ type HTMLElemSelfClosing interface {
 	 */
 	ID(v string) HTMLElemSelfClosing
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLElemSelfClosing
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLElemSelfClosing
@@ -17027,6 +17319,11 @@ func (e *htmlElemSelfClosing) ID(v string) HTMLElemSelfClosing {
 	return e
 }
 
+func (e *htmlElemSelfClosing) Key(v string) HTMLElemSelfClosing {
+	e.key = v
+	return e
+}
+
 func (e *htmlElemSelfClosing) Lang(v string) HTMLElemSelfClosing {
 	e.setAttr("lang", v)
 	return e
@@ -17299,6 +17596,10 @@ type HTMLEm interface {
 	 */
 	ID(v string) HTMLEm
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLEm
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLEm
@@ -17542,6 +17843,11 @@ func (e *htmlEm) ID(v string) HTMLEm {
 	return e
 }
 
+func (e *htmlEm) Key(v string) HTMLEm {
+	e.key = v
+	return e
+}
+
 func (e *htmlEm) Lang(v string) HTMLEm {
 	e.setAttr("lang", v)
 	return e
@@ -17807,6 +18113,10 @@ type HTMLEmbed interface {
 	 */
 	ID(v string) HTMLEmbed
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLEmbed
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLEmbed
@@ -18150,6 +18460,11 @@ func (e *htmlEmbed) ID(v string) HTMLEmbed {
 	return e
 }
 
+func (e *htmlEmbed) Key(v string) HTMLEmbed {
+	e.key = v
+	return e
+}
+
 func (e *htmlEmbed) Lang(v string) HTMLEmbed {
 	e.setAttr("lang", v)
 	return e
@@ -18555,6 +18870,10 @@ type HTMLFieldSet interface {
 	 */
 	ID(v string) HTMLFieldSet
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLFieldSet
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLFieldSet
@@ -18812,6 +19131,11 @@ func (e *htmlFieldSet) ID(v string) HTMLFieldSet {
 	return e
 }
 
+func (e *htmlFieldSet) Key(v string) HTMLFieldSet {
+	e.key = v
+	return e
+}
+
 func (e *htmlFieldSet) Lang(v string) HTMLFieldSet {
 	e.setAttr("lang", v)
 	return e
@@ -19084,6 +19408,10 @@ type HTMLFigCaption interface {
 	 */
 	ID(v string) HTMLFigCaption
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLFigCaption
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLFigCaption
@@ -19327,6 +19655,11 @@ func (e *htmlFigCaption) ID(v string) HTMLFigCaption {
 	return e
 }
 
+func (e *htmlFigCaption) Key(v string) HTMLFigCaption {
+	e.key = v
+	return e
+}
+
 func (e *htmlFigCaption) Lang(v string) HTMLFigCaption {
 	e.setAttr("lang", v)
 	return e
@@ -19594,6 +19927,10 @@ type HTMLFigure interface {
 	 */
 	ID(v string) HTMLFigure
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLFigure
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLFigure
@@ -19837,6 +20174,11 @@ func (e *htmlFigure) ID(v string) HTMLFigure {
 	return e
 }
 
+func (e *htmlFigure) Key(v string) HTMLFigure {
+	e.key = v
+	return e
+}
+
 func (e *htmlFigure) Lang(v string) HTMLFigure {
 	e.setAttr("lang", v)
 	return e
@@ -20104,6 +20446,10 @@ type HTMLFooter interface {
 	 */
 	ID(v string) HTMLFooter
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLFooter
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLFooter
@@ -20347,6 +20693,11 @@ func (e *htmlFooter) ID(v string) HTMLFooter {
 	return e
 }
 
+func (e *htmlFooter) Key(v string) HTMLFooter {
+	e.key = v
+	return e
+}
+
 func (e *htmlFooter) Lang(v string) HTMLFooter {
 	e.setAttr("lang", v)
 	return e
@@ -20630,6 +20981,10 @@ type HTMLForm interface {
 	 */
 	ID(v string) HTMLForm
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLForm
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLForm
@@ -20914,6 +21269,11 @@ func (e *htmlForm) ID(v string) HTMLForm {
 	return e
 }
 
+func (e *htmlForm) Key(v string) HTMLForm {
+	e.key = v
+	return e
+}
+
 func (e *htmlForm) Lang(v string) HTMLForm {
 	e.setAttr("lang", v)
 	return e
@@ -21201,6 +21561,10 @@ type HTMLH1 interface {
 	 */
 	ID(v string) HTMLH1
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLH1
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLH1
@@ -21444,6 +21808,11 @@ func (e *htmlH1) ID(v string) HTMLH1 {
 	return e
 }
 
+func (e *htmlH1) Key(v string) HTMLH1 {
+	e.key = v
+	return e
+}
+
 func (e *htmlH1) Lang(v string) HTMLH1 {
 	e.setAttr("lang", v)
 	return e
@@ -21711,6 +22080,10 @@ type HTMLH2 interface {
 	 */
 	ID(v string) HTMLH2
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLH2
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLH2
@@ -21954,6 +22327,11 @@ func (e *htmlH2) ID(v string) HTMLH2 {
 	return e
 }
 
+func (e *htmlH2) Key(v string) HTMLH2 {
+	e.key = v
+	return e
+}
+
 func (e *htmlH2) Lang(v string) HTMLH2 {
 	e.setAttr("lang", v)
 	return e
@@ -22221,6 +22599,10 @@ type HTMLH3 interface {
 	 */
 	ID(v string) HTMLH3
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLH3
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLH3
@@ -22464,6 +22846,11 @@ func (e *htmlH3) ID(v string) HTMLH3 {
 	return e
 }
 
+func (e *htmlH3) Key(v string) HTMLH3 {
+	e.key = v
+	return e
+}
+
 func (e *htmlH3) Lang(v string) HTMLH3 {
 	e.setAttr("lang", v)
 	return e
@@ -22731,6 +23118,10 @@ type HTMLH4 interface {
 	 */
 	ID(v string) HTMLH4
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLH4
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLH4
@@ -22974,6 +23365,11 @@ func (e *htmlH4) ID(v string) HTMLH4 {
 	return e
 }
 
+func (e *htmlH4) Key(v string) HTMLH4 {
+	e.key = v
+	return e
+}
+
 func (e *htmlH4) Lang(v string) HTMLH4 {
 	e.setAttr("lang", v)
 	return e
@@ -23241,6 +23637,10 @@ type HTMLH5 interface {
 	 */
 	ID(v string) HTMLH5
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLH5
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLH5
@@ -23484,6 +23884,11 @@ func (e *htmlH5) ID(v string) HTMLH5 {
 	return e
 }
 
+func (e *htmlH5) Key(v string) HTMLH5 {
+	e.key = v
+	return e
+}
+
 func (e *htmlH5) Lang(v string) HTMLH5 {
 	e.setAttr("lang", v)
 	return e
@@ -23751,6 +24156,10 @@ type HTMLH6 interface {
 	 */
 	ID(v string) HTMLH6
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLH6
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLH6
@@ -23994,6 +24403,11 @@ func (e *htmlH6) ID(v string) HTMLH6 {
 	return e
 }
 
+func (e *htmlH6) Key(v string) HTMLH6 {
+	e.key = v
+	return e
+}
+
 func (e *htmlH6) Lang(v string) HTMLH6 {
 	e.setAttr("lang", v)
 	return e
@@ -24261,6 +24675,10 @@ type HTMLHead interface {
 	 */
 	ID(v string) HTMLHead
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLHead
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLHead
@@ -24368,6 +24786,11 @@ func (e *htmlHead) ID(v string) HTMLHead {
 	return e
 }
 
+func (e *htmlHead) Key(v string) HTMLHead {
+	e.key = v
+	return e
+}
+
 func (e *htmlHead) Lang(v string) HTMLHead {
 	e.setAttr("lang", v)
 	return e
@@ -24465,6 +24888,10 @@ type HTMLHeader interface {
 	 */
 	ID(v string) HTMLHeader
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLHeader
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLHeader
@@ -24708,6 +25135,11 @@ func (e *htmlHeader) ID(v string) HTMLHeader {
 	return e
 }
 
+func (e *htmlHeader) Key(v string) HTMLHeader {
+	e.key = v
+	return e
+}
+
 func (e *htmlHeader) Lang(v string) HTMLHeader {
 	e.setAttr("lang", v)
 	return e
@@ -24969,6 +25401,10 @@ type HTMLHr interface {
 	 */
 	ID(v string) HTMLHr
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLHr
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLHr
@@ -25203,6 +25639,11 @@ func (e *htmlHr) ID(v string) HTMLHr {
 	return e
 }
 
+func (e *htmlHr) Key(v string) HTMLHr {
+	e.key = v
+	return e
+}
+
 func (e *htmlHr) Lang(v string) HTMLHr {
 	e.setAttr("lang", v)
 	return e
@@ -25466,6 +25907,10 @@ type HTMLHtml interface {
 	 */
 	ID(v string) HTMLHtml
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLHtml
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLHtml
@@ -25569,6 +26014,11 @@ func (e *htmlHtml) ID(v string) HTMLHtml {
 	return e
 }
 
+func (e *htmlHtml) Key(v string) HTMLHtml {
+	e.key = v
+	return e
+}
+
 func (e *htmlHtml) Lang(v string) HTMLHtml {
 	e.setAttr("lang", v)
 	return e
@@ -25666,6 +26116,10 @@ type HTMLI interface {
 	 */
 	ID(v string) HTMLI
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLI
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLI
@@ -25909,6 +26363,11 @@ func (e *htmlI) ID(v string) HTMLI {
 	return e
 }
 
+func (e *htmlI) Key(v string) HTMLI {
+	e.key = v
+	return e
+}
+
 func (e *htmlI) Lang(v string) HTMLI {
 	e.setAttr("lang", v)
 	return e
@@ -26192,6 +26651,10 @@ type HTMLIFrame interface {
 	 */
 	ID(v string) HTMLIFrame
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLIFrame
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLIFrame
@@ -26497,6 +26960,11 @@ func (e *htmlIFrame) ID(v string) HTMLIFrame {
 	return e
 }
 
+func (e *htmlIFrame) Key(v string) HTMLIFrame {
+	e.key = v
+	return e
+}
+
 func (e *htmlIFrame) Lang(v string) HTMLIFrame {
 	e.setAttr("lang", v)
 	return e
@@ -26814,6 +27282,10 @@ type HTMLImg interface {
 	 */
 	IsMap(v bool) HTMLImg
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLImg
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLImg
@@ -27184,6 +27656,11 @@ func (e *htmlImg) IsMap(v bool) HTMLImg {
 	return e
 }
 
+func (e *htmlImg) Key(v string) HTMLImg {
+	e.key = v
+	return e
+}
+
 func (e *htmlImg) Lang(v string) HTMLImg {
 	e.setAttr("lang", v)
 	return e
@@ -27650,6 +28127,10 @@ type HTMLInput interface {
 	 */
 	ID(v string) HTMLInput
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLInput
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLInput
@@ -28032,6 +28513,11 @@ func (e *htmlInput) ID(v string) HTMLInput {
 	return e
 }
 
+func (e *htmlInput) Key(v string) HTMLInput {
+	e.key = v
+	return e
+}
+
 func (e *htmlInput) Lang(v string) HTMLInput {
 	e.setAttr("lang", v)
 	return e
@@ -28384,6 +28870,10 @@ type HTMLIns interface {
 	 */
 	ID(v string) HTMLIns
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLIns
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLIns
@@ -28627,6 +29117,11 @@ func (e *htmlIns) ID(v string) HTMLIns {
 	return e
 }
 
+func (e *htmlIns) Key(v string) HTMLIns {
+	e.key = v
+	return e
+}
+
 func (e *htmlIns) Lang(v string) HTMLIns {
 	e.setAttr("lang", v)
 	return e
@@ -28894,6 +29389,10 @@ type HTMLKbd interface {
 	 */
 	ID(v string) HTMLKbd
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLKbd
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLKbd
@@ -29137,6 +29636,11 @@ func (e *htmlKbd) ID(v string) HTMLKbd {
 	return e
 }
 
+func (e *htmlKbd) Key(v string) HTMLKbd {
+	e.key = v
+	return e
+}
+
 func (e *htmlKbd) Lang(v string) HTMLKbd {
 	e.setAttr("lang", v)
 	return e
@@ -29412,6 +29916,10 @@ type HTMLLabel interface {
 	 */
 	ID(v string) HTMLLabel
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLLabel
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLLabel
@@ -29665,6 +30173,11 @@ func (e *htmlLabel) ID(v string) HTMLLabel {
 	return e
 }
 
+func (e *htmlLabel) Key(v string) HTMLLabel {
+	e.key = v
+	return e
+}
+
 func (e *htmlLabel) Lang(v string) HTMLLabel {
 	e.setAttr("lang", v)
 	return e
@@ -29932,6 +30445,10 @@ type HTMLLegend interface {
 	 */
 	ID(v string) HTMLLegend
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLLegend
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLLegend
@@ -30175,6 +30692,11 @@ func (e *htmlLegend) ID(v string) HTMLLegend {
 	return e
 }
 
+func (e *htmlLegend) Key(v string) HTMLLegend {
+	e.key = v
+	return e
+}
+
 func (e *htmlLegend) Lang(v string) HTMLLegend {
 	e.setAttr("lang", v)
 	return e
@@ -30442,6 +30964,10 @@ type HTMLLi interface {
 	 */
 	ID(v string) HTMLLi
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLLi
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLLi
@@ -30689,6 +31215,11 @@ func (e *htmlLi) ID(v string) HTMLLi {
 	return e
 }
 
+func (e *htmlLi) Key(v string) HTMLLi {
+	e.key = v
+	return e
+}
+
 func (e *htmlLi) Lang(v string) HTMLLi {
 	e.setAttr("lang", v)
 	return e
@@ -30967,6 +31498,10 @@ type HTMLLink interface {
 	 */
 	ID(v string) HTMLLink
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLLink
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLLink
@@ -31236,6 +31771,11 @@ func (e *htmlLink) ID(v string) HTMLLink {
 	return e
 }
 
+func (e *htmlLink) Key(v string) HTMLLink {
+	e.key = v
+	return e
+}
+
 func (e *htmlLink) Lang(v string) HTMLLink {
 	e.setAttr("lang", v)
 	return e
@@ -31528,6 +32068,10 @@ type HTMLMain interface {
 	 */
 	ID(v string) HTMLMain
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLMain
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLMain
@@ -31771,6 +32315,11 @@ func (e *htmlMain) ID(v string) HTMLMain {
 	return e
 }
 
+func (e *htmlMain) Key(v string) HTMLMain {
+	e.key = v
+	return e
+}
+
 func (e *htmlMain) Lang(v string) HTMLMain {
 	e.setAttr("lang", v)
 	return e
@@ -32038,6 +32587,10 @@ type HTMLMap interface {
 	 */
 	ID(v string) HTMLMap
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLMap
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLMap
@@ -32285,6 +32838,11 @@ func (e *htmlMap) ID(v string) HTMLMap {
 	return e
 }
 
+func (e *htmlMap) Key(v string) HTMLMap {
+	e.key = v
+	return e
+}
+
 func (e *htmlMap) Lang(v string) HTMLMap {
 	e.setAttr("lang", v)
 	return e
@@ -32557,6 +33115,10 @@ type HTMLMark interface {
 	 */
 	ID(v string) HTMLMark
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLMark
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLMark
@@ -32800,6 +33362,11 @@ func (e *htmlMark) ID(v string) HTMLMark {
 	return e
 }
 
+func (e *htmlMark) Key(v string) HTMLMark {
+	e.key = v
+	return e
+}
+
 func (e *htmlMark) Lang(v string) HTMLMark {
 	e.setAttr("lang", v)
 	return e
@@ -33073,6 +33640,10 @@ type HTMLMeta interface {
 	 */
 	ID(v string) HTMLMeta
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLMeta
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLMeta
@@ -33194,6 +33765,11 @@ func (e *htmlMeta) ID(v string) HTMLMeta {
 	return e
 }
 
+func (e *htmlMeta) Key(v string) HTMLMeta {
+	e.key = v
+	return e
+}
+
 func (e *htmlMeta) Lang(v string) HTMLMeta {
 	e.setAttr("lang", v)
 	return e
@@ -33309,6 +33885,10 @@ type HTMLMeter interface {
 	 */
 	ID(v string) HTMLMeter
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLMeter
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLMeter
@@ -33582,6 +34162,11 @@ func (e *htmlMeter) ID(v string) HTMLMeter {
 	return e
 }
 
+func (e *htmlMeter) Key(v string) HTMLMeter {
+	e.key = v
+	return e
+}
+
 func (e *htmlMeter) Lang(v string) HTMLMeter {
 	e.setAttr("lang", v)
 	return e
@@ -33874,6 +34459,10 @@ type HTMLNav interface {
 	 */
 	ID(v string) HTMLNav
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLNav
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLNav
@@ -34117,6 +34706,11 @@ func (e *htmlNav) ID(v string) HTMLNav {
 	return e
 }
 
+func (e *htmlNav) Key(v string) HTMLNav {
+	e.key = v
+	return e
+}
+
 func (e *htmlNav) Lang(v string) HTMLNav {
 	e.setAttr("lang", v)
 	return e
@@ -34384,6 +34978,10 @@ type HTMLNoScript interface {
 	 */
 	ID(v string) HTMLNoScript
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLNoScript
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLNoScript
@@ -34491,6 +35089,11 @@ func (e *htmlNoScript) ID(v string) HTMLNoScript {
 	return e
 }
 
+func (e *htmlNoScript) Key(v string) HTMLNoScript {
+	e.key = v
+	return e
+}
+
 func (e *htmlNoScript) Lang(v string) HTMLNoScript {
 	e.setAttr("lang", v)
 	return e
@@ -34600,6 +35203,10 @@ type HTMLObject interface {
 	 */
 	ID(v string) HTMLObject
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLObject
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLObject
@@ -34966,6 +35573,11 @@ func (e *htmlObject) ID(v string) HTMLObject {
 	return e
 }
 
+func (e *htmlObject) Key(v string) HTMLObject {
+	e.key = v
+	return e
+}
+
 func (e *htmlObject) Lang(v string) HTMLObject {
 	e.setAttr("lang", v)
 	return e
@@ -35368,6 +35980,10 @@ type HTMLOl interface {
 	 */
 	ID(v string) HTMLOl
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLOl
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLOl
@@ -35623,6 +36239,11 @@ func (e *htmlOl) ID(v string) HTMLOl {
 	return e
 }
 
+func (e *htmlOl) Key(v string) HTMLOl {
+	e.key = v
+	return e
+}
+
 func (e *htmlOl) Lang(v string) HTMLOl {
 	e.setAttr("lang", v)
 	return e
@@ -35909,6 +36530,10 @@ type HTMLOptGroup interface {
 	 */
 	ID(v string) HTMLOptGroup
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLOptGroup
+
 	/* Label specifies a shorter label for the option.
 	 */
 	Label(v string) HTMLOptGroup
@@ -36161,6 +36786,11 @@ func (e *htmlOptGroup) ID(v string) HTMLOptGroup {
 	return e
 }
 
+func (e *htmlOptGroup) Key(v string) HTMLOptGroup {
+	e.key = v
+	return e
+}
+
 func (e *htmlOptGroup) Label(v string) HTMLOptGroup {
 	e.setAttr("label", v)
 	return e
@@ -36437,6 +37067,10 @@ type HTMLOption interface {
 	 */
 	ID(v string) HTMLOption
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLOption
+
 	/* Label specifies a shorter label for the option.
 	 */
 	Label(v string) HTMLOption
@@ -36697,6 +37331,11 @@ func (e *htmlOption) ID(v string) HTMLOption {
 	return e
 }
 
+func (e *htmlOption) Key(v string) HTMLOption {
+	e.key = v
+	return e
+}
+
 func (e *htmlOption) Label(v string) HTMLOption {
 	e.setAttr("label", v)
 	return e
@@ -36987,6 +37626,10 @@ type HTMLOutput interface {
 	 */
 	ID(v string) HTMLOutput
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLOutput
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLOutput
@@ -37244,6 +37887,11 @@ func (e *htmlOutput) ID(v string) HTMLOutput {
 	return e
 }
 
+func (e *htmlOutput) Key(v string) HTMLOutput {
+	e.key = v
+	return e
+}
+
 func (e *htmlOutput) Lang(v string) HTMLOutput {
 	e.setAttr("lang", v)
 	return e
@@ -37516,6 +38164,10 @@ type HTMLP interface {
 	 */
 	ID(v string) HTMLP
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLP
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLP
@@ -37759,6 +38411,11 @@ func (e *htmlP) ID(v string) HTMLP {
 	return e
 }
 
+func (e *htmlP) Key(v string) HTMLP {
+	e.key = v
+	return e
+}
+
 func (e *htmlP) Lang(v string) HTMLP {
 	e.setAttr("lang", v)
 	return e
@@ -38020,6 +38677,10 @@ type HTMLParam interface {
 	 */
 	ID(v string) HTMLParam
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLParam
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLParam
@@ -38262,6 +38923,11 @@ func (e *htmlParam) ID(v string) HTMLParam {
 	return e
 }
 
+func (e *htmlParam) Key(v string) HTMLParam {
+	e.key = v
+	return e
+}
+
 func (e *htmlParam) Lang(v string) HTMLParam {
 	e.setAttr("lang", v)
 	return e
@@ -38539,6 +39205,10 @@ type HTMLPicture interface {
 	 */
 	ID(v string) HTMLPicture
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLPicture
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLPicture
@@ -38782,6 +39452,11 @@ func (e *htmlPicture) ID(v string) HTMLPicture {
 	return e
 }
 
+func (e *htmlPicture) Key(v string) HTMLPicture {
+	e.key = v
+	return e
+}
+
 func (e *htmlPicture) Lang(v string) HTMLPicture {
 	e.setAttr("lang", v)
 	return e
@@ -39049,6 +39724,10 @@ type HTMLPre interface {
 	 */
 	ID(v string) HTMLPre
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLPre
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLPre
@@ -39292,6 +39971,11 @@ func (e *htmlPre) ID(v string) HTMLPre {
 	return e
 }
 
+func (e *htmlPre) Key(v string) HTMLPre {
+	e.key = v
+	return e
+}
+
 func (e *htmlPre) Lang(v string) HTMLPre {
 	e.setAttr("lang", v)
 	return e
@@ -39559,6 +40243,10 @@ type HTMLProgress interface {
 	 */
 	ID(v string) HTMLProgress
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLProgress
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLProgress
@@ -39810,6 +40498,11 @@ func (e *htmlProgress) ID(v string) HTMLProgress {
 	return e
 }
 
+func (e *htmlProgress) Key(v string) HTMLProgress {
+	e.key = v
+	return e
+}
+
 func (e *htmlProgress) Lang(v string) HTMLProgress {
 	e.setAttr("lang", v)
 	return e
@@ -40091,6 +40784,10 @@ type HTMLQ interface {
 	 */
 	ID(v string) HTMLQ
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLQ
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLQ
@@ -40339,6 +41036,11 @@ func (e *htmlQ) ID(v string) HTMLQ {
 	return e
 }
 
+func (e *htmlQ) Key(v string) HTMLQ {
+	e.key = v
+	return e
+}
+
 func (e *htmlQ) Lang(v string) HTMLQ {
 	e.setAttr("lang", v)
 	return e
@@ -40606,6 +41308,10 @@ type HTMLRp interface {
 	 */
 	ID(v string) HTMLRp
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLRp
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLRp
@@ -40849,6 +41555,11 @@ func (e *htmlRp) ID(v string) HTMLRp {
 	return e
 }
 
+func (e *htmlRp) Key(v string) HTMLRp {
+	e.key = v
+	return e
+}
+
 func (e *htmlRp) Lang(v string) HTMLRp {
 	e.setAttr("lang", v)
 	return e
@@ -41116,6 +41827,10 @@ type HTMLRt interface {
 	 */
 	ID(v string) HTMLRt
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLRt
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLRt
@@ -41359,6 +42074,11 @@ func (e *htmlRt) ID(v string) HTMLRt {
 	return e
 }
 
+func (e *htmlRt) Key(v string) HTMLRt {
+	e.key = v
+	return e
+}
+
 func (e *htmlRt) Lang(v string) HTMLRt {
 	e.setAttr("lang", v)
 	return e
@@ -41626,6 +42346,10 @@ type HTMLRuby interface {
 	 */
 	ID(v string) HTMLRuby
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLRuby
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLRuby
@@ -41869,6 +42593,11 @@ func (e *htmlRuby) ID(v string) HTMLRuby {
 	return e
 }
 
+func (e *htmlRuby) Key(v string) HTMLRuby {
+	e.key = v
+	return e
+}
+
 func (e *htmlRuby) Lang(v string) HTMLRuby {
 	e.setAttr("lang", v)
 	return e
@@ -42136,6 +42865,10 @@ type HTMLS interface {
 	 */
 	ID(v string) HTMLS
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLS
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLS
@@ -42379,6 +43112,11 @@ func (e *htmlS) ID(v string) HTMLS {
 	return e
 }
 
+func (e *htmlS) Key(v string) HTMLS {
+	e.key = v
+	return e
+}
+
 func (e *htmlS) Lang(v string) HTMLS {
 	e.setAttr("lang", v)
 	return e
@@ -42646,6 +43384,10 @@ type HTMLSamp interface {
 	 */
 	ID(v string) HTMLSamp
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLSamp
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLSamp
@@ -42889,6 +43631,11 @@ func (e *htmlSamp) ID(v string) HTMLSamp {
 	return e
 }
 
+func (e *htmlSamp) Key(v string) HTMLSamp {
+	e.key = v
+	return e
+}
+
 func (e *htmlSamp) Lang(v string) HTMLSamp {
 	e.setAttr("lang", v)
 	return e
@@ -43172,6 +43919,10 @@ type HTMLScript interface {
 	 */
 	ID(v string) HTMLScript
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLScript
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLScript
@@ -43311,6 +44062,11 @@ func (e *htmlScript) ID(v string) HTMLScript {
 	return e
 }
 
+func (e *htmlScript) Key(v string) HTMLScript {
+	e.key = v
+	return e
+}
+
 func (e *htmlScript) Lang(v string) HTMLScript {
 	e.setAttr("lang", v)
 	return e
@@ -43423,6 +44179,10 @@ type HTMLSection interface {
 	 */
 	ID(v string) HTMLSection
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLSection
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLSection
@@ -43666,6 +44426,11 @@ func (e *htmlSection) ID(v string) HTMLSection {
 	return e
 }
 
+func (e *htmlSection) Key(v string) HTMLSection {
+	e.key = v
+	return e
+}
+
 func (e *htmlSection) Lang(v string) HTMLSection {
 	e.setAttr("lang", v)
 	return e
@@ -43945,6 +44710,10 @@ type HTMLSelect interface {
 	 */
 	ID(v string) HTMLSelect
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLSelect
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLSelect
@@ -44219,6 +44988,11 @@ func (e *htmlSelect) ID(v string) HTMLSelect {
 	return e
 }
 
+func (e *htmlSelect) Key(v string) HTMLSelect {
+	e.key = v
+	return e
+}
+
 func (e *htmlSelect) Lang(v string) HTMLSelect {
 	e.setAttr("lang", v)
 	return e
@@ -44506,6 +45280,10 @@ type HTMLSmall interface {
 	 */
 	ID(v string) HTMLSmall
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLSmall
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLSmall
@@ -44749,6 +45527,11 @@ func (e *htmlSmall) ID(v string) HTMLSmall {
 	return e
 }
 
+func (e *htmlSmall) Key(v string) HTMLSmall {
+	e.key = v
+	return e
+}
+
 func (e *htmlSmall) Lang(v string) HTMLSmall {
 	e.setAttr("lang", v)
 	return e
@@ -45010,6 +45793,10 @@ type HTMLSource interface {
 	 */
 	ID(v string) HTMLSource
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLSource
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLSource
@@ -45264,6 +46051,11 @@ func (e *htmlSource) ID(v string) HTMLSource {
 	return e
 }
 
+func (e *htmlSource) Key(v string) HTMLSource {
+	e.key = v
+	return e
+}
+
 func (e *htmlSource) Lang(v string) HTMLSource {
 	e.setAttr("lang", v)
 	return e
@@ -45556,6 +46348,10 @@ type HTMLSpan interface {
 	 */
 	ID(v string) HTMLSpan
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLSpan
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLSpan
@@ -45799,6 +46595,11 @@ func (e *htmlSpan) ID(v string) HTMLSpan {
 	return e
 }
 
+func (e *htmlSpan) Key(v string) HTMLSpan {
+	e.key = v
+	return e
+}
+
 func (e *htmlSpan) Lang(v string) HTMLSpan {
 	e.setAttr("lang", v)
 	return e
@@ -46066,6 +46867,10 @@ type HTMLStrong interface {
 	 */
 	ID(v string) HTMLStrong
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLStrong
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLStrong
@@ -46309,6 +47114,11 @@ func (e *htmlStrong) ID(v string) HTMLStrong {
 	return e
 }
 
+func (e *htmlStrong) Key(v string) HTMLStrong {
+	e.key = v
+	return e
+}
+
 func (e *htmlStrong) Lang(v string) HTMLStrong {
 	e.setAttr("lang", v)
 	return e
@@ -46576,6 +47386,10 @@ type HTMLStyle interface {
 	 */
 	ID(v string) HTMLStyle
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLStyle
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLStyle
@@ -46831,6 +47645,11 @@ func (e *htmlStyle) ID(v string) HTMLStyle {
 	return e
 }
 
+func (e *htmlStyle) Key(v string) HTMLStyle {
+	e.key = v
+	return e
+}
+
 func (e *htmlStyle) Lang(v string) HTMLStyle {
 	e.setAttr("lang", v)
 	return e
@@ -47113,6 +47932,10 @@ type HTMLSub interface {
 	 */
 	ID(v string) HTMLSub
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLSub
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLSub
@@ -47356,6 +48179,11 @@ func (e *htmlSub) ID(v string) HTMLSub {
 	return e
 }
 
+func (e *htmlSub) Key(v string) HTMLSub {
+	e.key = v
+	return e
+}
+
 func (e *htmlSub) Lang(v string) HTMLSub {
 	e.setAttr("lang", v)
 	return e
@@ -47623,6 +48451,10 @@ type HTMLSummary interface {
 	 */
 	ID(v string) HTMLSummary
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLSummary
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLSummary
@@ -47866,6 +48698,11 @@ func (e *htmlSummary) ID(v string) HTMLSummary {
 	return e
 }
 
+func (e *htmlSummary) Key(v string) HTMLSummary {
+	e.key = v
+	return e
+}
+
 func (e *htmlSummary) Lang(v string) HTMLSummary {
 	e.setAttr("lang", v)
 	return e
@@ -48133,6 +48970,10 @@ type HTMLSup interface {
 	 */
 	ID(v string) HTMLSup
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLSup
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLSup
@@ -48376,6 +49217,11 @@ func (e *htmlSup) ID(v string) HTMLSup {
 	return e
 }
 
+func (e *htmlSup) Key(v string) HTMLSup {
+	e.key = v
+	return e
+}
+
 func (e *htmlSup) Lang(v string) HTMLSup {
 	e.setAttr("lang", v)
 	return e
@@ -48643,6 +49489,10 @@ type HTMLTable interface {
 	 */
 	ID(v string) HTMLTable
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTable
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTable
@@ -48886,6 +49736,11 @@ func (e *htmlTable) ID(v string) HTMLTable {
 	return e
 }
 
+func (e *htmlTable) Key(v string) HTMLTable {
+	e.key = v
+	return e
+}
+
 func (e *htmlTable) Lang(v string) HTMLTable {
 	e.setAttr("lang", v)
 	return e
@@ -49153,6 +50008,10 @@ type HTMLTBody interface {
 	 */
 	ID(v string) HTMLTBody
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTBody
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTBody
@@ -49396,6 +50255,11 @@ func (e *htmlTBody) ID(v string) HTMLTBody {
 	return e
 }
 
+func (e *htmlTBody) Key(v string) HTMLTBody {
+	e.key = v
+	return e
+}
+
 func (e *htmlTBody) Lang(v string) HTMLTBody {
 	e.setAttr("lang", v)
 	return e
@@ -49671,6 +50535,10 @@ type HTMLTd interface {
 	 */
 	ID(v string) HTMLTd
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTd
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTd
@@ -49928,6 +50796,11 @@ func (e *htmlTd) ID(v string) HTMLTd {
 	return e
 }
 
+func (e *htmlTd) Key(v string) HTMLTd {
+	e.key = v
+	return e
+}
+
 func (e *htmlTd) Lang(v string) HTMLTd {
 	e.setAttr("lang", v)
 	return e
@@ -50200,6 +51073,10 @@ type HTMLTemplate interface {
 	 */
 	ID(v string) HTMLTemplate
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTemplate
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTemplate
@@ -50307,6 +51184,11 @@ func (e *htmlTemplate) ID(v string) HTMLTemplate {
 	return e
 }
 
+func (e *htmlTemplate) Key(v string) HTMLTemplate {
+	e.key = v
+	return e
+}
+
 func (e *htmlTemplate) Lang(v string) HTMLTemplate {
 	e.setAttr("lang", v)
 	return e
@@ -50424,6 +51306,10 @@ type HTMLTextarea interface {
 	 */
 	ID(v string) HTMLTextarea
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTextarea
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTextarea
@@ -50721,6 +51607,11 @@ func (e *htmlTextarea) ID(v string) HTMLTextarea {
 	return e
 }
 
+func (e *htmlTextarea) Key(v string) HTMLTextarea {
+	e.key = v
+	return e
+}
+
 func (e *htmlTextarea) Lang(v string) HTMLTextarea {
 	e.setAttr("lang", v)
 	return e
@@ -51023,6 +51914,10 @@ type HTMLTFoot interface {
 	 */
 	ID(v string) HTMLTFoot
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTFoot
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTFoot
@@ -51266,6 +52161,11 @@ func (e *htmlTFoot) ID(v string) HTMLTFoot {
 	return e
 }
 
+func (e *htmlTFoot) Key(v string) HTMLTFoot {
+	e.key = v
+	return e
+}
+
 func (e *htmlTFoot) Lang(v string) HTMLTFoot {
 	e.setAttr("lang", v)
 	return e
@@ -51545,6 +52445,10 @@ type HTMLTh interface {
 	 */
 	ID(v string) HTMLTh
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTh
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTh
@@ -51811,6 +52715,11 @@ func (e *htmlTh) ID(v string) HTMLTh {
 	return e
 }
 
+func (e *htmlTh) Key(v string) HTMLTh {
+	e.key = v
+	return e
+}
+
 func (e *htmlTh) Lang(v string) HTMLTh {
 	e.setAttr("lang", v)
 	return e
@@ -52088,6 +52997,10 @@ type HTMLTHead interface {
 	 */
 	ID(v string) HTMLTHead
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTHead
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTHead
@@ -52331,6 +53244,11 @@ func (e *htmlTHead) ID(v string) HTMLTHead {
 	return e
 }
 
+func (e *htmlTHead) Key(v string) HTMLTHead {
+	e.key = v
+	return e
+}
+
 func (e *htmlTHead) Lang(v string) HTMLTHead {
 	e.setAttr("lang", v)
 	return e
@@ -52602,6 +53520,10 @@ type HTMLTime interface {
 	 */
 	ID(v string) HTMLTime
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTime
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTime
@@ -52850,6 +53772,11 @@ func (e *htmlTime) ID(v string) HTMLTime {
 	return e
 }
 
+func (e *htmlTime) Key(v string) HTMLTime {
+	e.key = v
+	return e
+}
+
 func (e *htmlTime) Lang(v string) HTMLTime {
 	e.setAttr("lang", v)
 	return e
@@ -53117,6 +54044,10 @@ type HTMLTitle interface {
 	 */
 	ID(v string) HTMLTitle
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTitle
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTitle
@@ -53224,6 +54155,11 @@ func (e *htmlTitle) ID(v string) HTMLTitle {
 	return e
 }
 
+func (e *htmlTitle) Key(v string) HTMLTitle {
+	e.key = v
+	return e
+}
+
 func (e *htmlTitle) Lang(v string) HTMLTitle {
 	e.setAttr("lang", v)
 	return e
@@ -53321,6 +54257,10 @@ type HTMLTr interface {
 	 */
 	ID(v string) HTMLTr
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLTr
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLTr
@@ -53564,6 +54504,11 @@ func (e *htmlTr) ID(v string) HTMLTr {
 	return e
 }
 
+func (e *htmlTr) Key(v string) HTMLTr {
+	e.key = v
+	return e
+}
+
 func (e *htmlTr) Lang(v string) HTMLTr {
 	e.setAttr("lang", v)
 	return e
@@ -53831,6 +54776,10 @@ type HTMLU interface {
 	 */
 	ID(v string) HTMLU
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLU
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLU
@@ -54074,6 +55023,11 @@ func (e *htmlU) ID(v string) HTMLU {
 	return e
 }
 
+func (e *htmlU) Key(v string) HTMLU {
+	e.key = v
+	return e
+}
+
 func (e *htmlU) Lang(v string) HTMLU {
 	e.setAttr("lang", v)
 	return e
@@ -54341,6 +55295,10 @@ type HTMLUl interface {
 	 */
 	ID(v string) HTMLUl
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLUl
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLUl
@@ -54584,6 +55542,11 @@ func (e *htmlUl) ID(v string) HTMLUl {
 	return e
 }
 
+func (e *htmlUl) Key(v string) HTMLUl {
+	e.key = v
+	return e
+}
+
 func (e *htmlUl) Lang(v string) HTMLUl {
 	e.setAttr("lang", v)
 	return e
@@ -54851,6 +55814,10 @@ type HTMLVar interface {
 	 */
 	ID(v string) HTMLVar
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLVar
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLVar
@@ -55094,6 +56061,11 @@ func (e *htmlVar) ID(v string) HTMLVar {
 	return e
 }
 
+func (e *htmlVar) Key(v string) HTMLVar {
+	e.key = v
+	return e
+}
+
 func (e *htmlVar) Lang(v string) HTMLVar {
 	e.setAttr("lang", v)
 	return e
@@ -55377,6 +56349,10 @@ type HTMLVideo interface {
 	 */
 	ID(v string) HTMLVideo
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLVideo
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLVideo
@@ -55756,6 +56732,11 @@ func (e *htmlVideo) ID(v string) HTMLVideo {
 	return e
 }
 
+func (e *htmlVideo) Key(v string) HTMLVideo {
+	e.key = v
+	return e
+}
+
 func (e *htmlVideo) Lang(v string) HTMLVideo {
 	e.setAttr("lang", v)
 	return e
@@ -56168,6 +57149,10 @@ type HTMLWbr interface {
 	 */
 	ID(v string) HTMLWbr
 
+	/* Key specifies a key that identifies the element among its siblings. Keyed elements are moved rather than replaced when their position changes during an update.
+	 */
+	Key(v string) HTMLWbr
+
 	/* Lang specifies the language of the element's content.
 	 */
 	Lang(v string) HTMLWbr
@@ -56411,6 +57396,11 @@ func (e *htmlWbr) ID(v string) HTMLWbr {
 	return e
 }
 
+func (e *htmlWbr) Key(v string) HTMLWbr {
+	e.key = v
+	return e
+}
+
 func (e *htmlWbr) Lang(v string) HTMLWbr {
 	e.setAttr("lang", v)
 	return e
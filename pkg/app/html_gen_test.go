@@ -24,6 +24,7 @@ func TestA(t *testing.T) {
 	elem.Href("http://foo.com")
 	elem.HrefLang("foo")
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Media("foo")
 	elem.Ping("foo")
@@ -92,6 +93,7 @@ func TestAbbr(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -155,6 +157,7 @@ func TestAddress(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -223,6 +226,7 @@ func TestArea(t *testing.T) {
 	elem.Href("http://foo.com")
 	elem.HrefLang("foo")
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Media("foo")
 	elem.Rel("foo")
@@ -290,6 +294,7 @@ func TestArticle(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -353,6 +358,7 @@ func TestAside(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -421,6 +427,7 @@ func TestAudio(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Loop(true)
 	elem.Loop(false)
@@ -513,6 +520,7 @@ func TestB(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -577,6 +585,7 @@ func TestBase(t *testing.T) {
 	elem.Hidden(false)
 	elem.Href("http://foo.com")
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -640,6 +649,7 @@ func TestBdi(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -703,6 +713,7 @@ func TestBdo(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -767,6 +778,7 @@ func TestBlockquote(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -830,6 +842,7 @@ func TestBody(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -908,6 +921,7 @@ func TestBr(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -981,6 +995,7 @@ func TestButton(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Name("foo")
 	elem.Role("foo")
@@ -1048,6 +1063,7 @@ func TestCanvas(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1112,6 +1128,7 @@ func TestCaption(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1175,6 +1192,7 @@ func TestCite(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1238,6 +1256,7 @@ func TestCode(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1301,6 +1320,7 @@ func TestCol(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Span(42)
@@ -1364,6 +1384,7 @@ func TestColGroup(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Span(42)
@@ -1428,6 +1449,7 @@ func TestData(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1455,6 +1477,7 @@ func TestDataList(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1518,6 +1541,7 @@ func TestDd(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1583,6 +1607,7 @@ func TestDel(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1646,6 +1671,7 @@ func TestDetails(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Open(true)
 	elem.Open(false)
@@ -1712,6 +1738,7 @@ func TestDfn(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1775,6 +1802,7 @@ func TestDialog(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Open(true)
 	elem.Open(false)
@@ -1840,6 +1868,7 @@ func TestDiv(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1903,6 +1932,7 @@ func TestDl(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -1966,6 +1996,7 @@ func TestDt(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2029,6 +2060,7 @@ func TestElem(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2093,6 +2125,7 @@ func TestElemSelfClosing(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2156,6 +2189,7 @@ func TestEm(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2220,6 +2254,7 @@ func TestEmbed(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2311,6 +2346,7 @@ func TestFieldSet(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Name("foo")
 	elem.Role("foo")
@@ -2375,6 +2411,7 @@ func TestFigCaption(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2438,6 +2475,7 @@ func TestFigure(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2501,6 +2539,7 @@ func TestFooter(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2569,6 +2608,7 @@ func TestForm(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Method("foo")
 	elem.Name("foo")
@@ -2637,6 +2677,7 @@ func TestH1(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2700,6 +2741,7 @@ func TestH2(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2763,6 +2805,7 @@ func TestH3(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2826,6 +2869,7 @@ func TestH4(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2889,6 +2933,7 @@ func TestH5(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -2952,6 +2997,7 @@ func TestH6(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3015,6 +3061,7 @@ func TestHead(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3041,6 +3088,7 @@ func TestHeader(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3104,6 +3152,7 @@ func TestHr(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3166,6 +3215,7 @@ func TestHtml(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3192,6 +3242,7 @@ func TestI(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3261,6 +3312,7 @@ func TestIFrame(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Loading("foo")
 	elem.Name("foo")
@@ -3337,6 +3389,7 @@ func TestImg(t *testing.T) {
 	elem.ID("foo")
 	elem.IsMap(true)
 	elem.IsMap(false)
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Sizes("foo")
@@ -3448,6 +3501,7 @@ func TestInput(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.List("foo")
 	elem.Max(42)
@@ -3530,6 +3584,7 @@ func TestIns(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3593,6 +3648,7 @@ func TestKbd(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3658,6 +3714,7 @@ func TestLabel(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3721,6 +3778,7 @@ func TestLegend(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3784,6 +3842,7 @@ func TestLi(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3851,6 +3910,7 @@ func TestLink(t *testing.T) {
 	elem.Href("http://foo.com")
 	elem.HrefLang("foo")
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Media("foo")
 	elem.Rel("foo")
@@ -3918,6 +3978,7 @@ func TestMain(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -3981,6 +4042,7 @@ func TestMap(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Name("foo")
 	elem.Role("foo")
@@ -4045,6 +4107,7 @@ func TestMark(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -4111,6 +4174,7 @@ func TestMeta(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Name("foo")
 	elem.Property("foo")
@@ -4140,6 +4204,7 @@ func TestMeter(t *testing.T) {
 	elem.Hidden(false)
 	elem.High(42)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Low(42)
 	elem.Max(42)
@@ -4208,6 +4273,7 @@ func TestNav(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -4271,6 +4337,7 @@ func TestNoScript(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -4300,6 +4367,7 @@ func TestObject(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Name("foo")
 	elem.Role("foo")
@@ -4390,6 +4458,7 @@ func TestOl(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Reversed(true)
 	elem.Reversed(false)
@@ -4459,6 +4528,7 @@ func TestOptGroup(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Label("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
@@ -4525,6 +4595,7 @@ func TestOption(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Label("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
@@ -4594,6 +4665,7 @@ func TestOutput(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Name("foo")
 	elem.Role("foo")
@@ -4658,6 +4730,7 @@ func TestP(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -4721,6 +4794,7 @@ func TestParam(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Name("foo")
 	elem.Role("foo")
@@ -4785,6 +4859,7 @@ func TestPicture(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -4848,6 +4923,7 @@ func TestPre(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -4911,6 +4987,7 @@ func TestProgress(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Max(42)
 	elem.Role("foo")
@@ -4977,6 +5054,7 @@ func TestQ(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5040,6 +5118,7 @@ func TestRp(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5103,6 +5182,7 @@ func TestRt(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5166,6 +5246,7 @@ func TestRuby(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5229,6 +5310,7 @@ func TestS(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5292,6 +5374,7 @@ func TestSamp(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5361,6 +5444,7 @@ func TestScript(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5393,6 +5477,7 @@ func TestSection(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5461,6 +5546,7 @@ func TestSelect(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Multiple(true)
 	elem.Multiple(false)
@@ -5530,6 +5616,7 @@ func TestSmall(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5593,6 +5680,7 @@ func TestSource(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Media("foo")
 	elem.Role("foo")
@@ -5660,6 +5748,7 @@ func TestSpan(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5723,6 +5812,7 @@ func TestStrong(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5786,6 +5876,7 @@ func TestStyle(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Media("foo")
 	elem.Role("foo")
@@ -5852,6 +5943,7 @@ func TestSub(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5915,6 +6007,7 @@ func TestSummary(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -5978,6 +6071,7 @@ func TestSup(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6041,6 +6135,7 @@ func TestTable(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6104,6 +6199,7 @@ func TestTBody(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6169,6 +6265,7 @@ func TestTd(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Rowspan(42)
@@ -6233,6 +6330,7 @@ func TestTemplate(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6266,6 +6364,7 @@ func TestTextarea(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.MaxLength(42)
 	elem.Name("foo")
@@ -6338,6 +6437,7 @@ func TestTFoot(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6404,6 +6504,7 @@ func TestTh(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Rowspan(42)
@@ -6469,6 +6570,7 @@ func TestTHead(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6533,6 +6635,7 @@ func TestTime(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6596,6 +6699,7 @@ func TestTitle(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6622,6 +6726,7 @@ func TestTr(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6685,6 +6790,7 @@ func TestU(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6748,6 +6854,7 @@ func TestUl(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6811,6 +6918,7 @@ func TestVar(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
@@ -6880,6 +6988,7 @@ func TestVideo(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Loop(true)
 	elem.Loop(false)
@@ -6974,6 +7083,7 @@ func TestWbr(t *testing.T) {
 	elem.Hidden(true)
 	elem.Hidden(false)
 	elem.ID("foo")
+	elem.Key("foo")
 	elem.Lang("foo")
 	elem.Role("foo")
 	elem.Spellcheck(true)
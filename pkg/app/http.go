@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"os"
 	"reflect"
@@ -25,6 +26,15 @@ const (
 	defaultThemeColor         = "#2d2c2c"
 	defaultPreRenderCacheSize = 8000000
 	defaultPreRenderCacheTTL  = time.Hour * 24
+
+	// The maximum size of an action called from the client.
+	serverActionMaxBodySize = 1 << 20
+
+	// The header that the client sets when calling a server action. Browsers
+	// do not send custom headers with cross-site requests without a CORS
+	// preflight, which prevents other sites from calling server actions with
+	// the user cookies.
+	serverActionHeader = "X-Goapp-Action"
 )
 
 // Handler is an HTTP handler that serves an HTML page that loads a Go wasm app
@@ -137,8 +147,45 @@ type Handler struct {
 	// Reserved keys:
 	// - GOAPP_VERSION
 	// - GOAPP_GOAPP_STATIC_RESOURCES_URL
+	// - GOAPP_HYDRATE
+	// - GOAPP_STATE_SYNC_URL
+	// - GOAPP_SERVER_ACTIONS_URL
 	Env Environment
 
+	// Reports whether the pre-rendered page markup is reused when the app
+	// starts in the web browser. When enabled, the loading screen is not
+	// displayed and the first mounted component attaches itself to the
+	// existing HTML nodes instead of replacing them.
+	//
+	// Components must render the same UI elements on both server and client
+	// sides. Mismatching nodes are discarded and rendered again.
+	//
+	// Default: false.
+	Hydrate bool
+
+	// Reports whether the states set with the Sync option are synchronized with
+	// the server. When enabled, the handler serves the /app-states endpoint
+	// that pushes state changes to the clients with server-sent events and
+	// receives the states set by the clients.
+	//
+	// States are set from the server with SetState and SetClientState, and are
+	// read with GetClientState.
+	//
+	// Requests to the /app-states endpoint are served only when they are
+	// accepted by AuthorizeStateSync.
+	//
+	// Default: false.
+	SyncStates bool
+
+	// The function that reports whether a request can read and set the synced
+	// states of the client with the given ID. It is called for each request to
+	// the /app-states endpoint and usually checks that the client belongs to
+	// the user authenticated by the request cookies. Requests that are not
+	// authorized get a 403 response.
+	//
+	// Default: nil, which rejects all the requests.
+	AuthorizeStateSync func(r *http.Request, clientID string) bool
+
 	// The URLs that are launched in the app tab or window.
 	//
 	// By default, URLs with a different domain are launched in another tab.
@@ -155,6 +202,55 @@ type Handler struct {
 	// The Control-Cache header value for pre-rendered resources.
 	PreRenderCacheControl string
 
+	// The function that returns the key under which a pre-rendered page is
+	// stored in the pre-render cache. Requests that produce different pages
+	// must have different keys. PreRenderCacheVariants.Key can be used to
+	// include the query, the host, headers or cookies into the key.
+	//
+	// The ETag of a page is derived from its key, which makes browsers get the
+	// page again when its key changes.
+	//
+	// Default: PreRenderCacheVariants.Key.
+	PreRenderCacheKey func(*http.Request) string
+
+	// The request attributes that distinguish pre-rendered pages in addition
+	// to the URL path. The headers and cookies it contains are listed in the
+	// Vary header of the pages, which prevents browsers and shared caches from
+	// reusing a page rendered for other values.
+	//
+	// Default: the URL path only.
+	PreRenderCacheVariants PreRenderCacheVariants
+
+	// The router that defines the routes, layouts, route middlewares and action
+	// handlers of the app.
+	//
+	// The client must be started with the same router by calling its
+	// RunWhenOnBrowser method.
+	//
+	// Default: the default router, populated by Route, RouteWithRegexp,
+	// RouteLayout, UseRouteMiddleware and Handle.
+	Router *Router
+
+	// Reports whether pre-rendered pages are streamed. When enabled, the page
+	// head and body are sent once the OnPreRender handlers are executed,
+	// without waiting for the goroutines they launch with Context.Async. The
+	// browser then starts loading styles, wasm_exec.js and app.js while the
+	// page is still being pre-rendered. The body content is streamed again
+	// each time it is modified by those goroutines, with an inline script that
+	// replaces the previously streamed content.
+	//
+	// The status code, headers and page information set by OnPreRender
+	// handlers are applied to the streamed response. Changes made afterward,
+	// from goroutines launched with Context.Async, cannot be sent and prevent
+	// the page from being stored in the pre-render cache. Cached pages contain
+	// the final content, without the streamed updates.
+	//
+	// Pages are buffered when the response writer does not implement
+	// http.Flusher.
+	//
+	// Default: false.
+	StreamPreRendering bool
+
 	// The static resources that are accessible from custom paths. Files that
 	// are proxied by default are /robots.txt, /sitemap.xml and /ads.txt.
 	ProxyResources []ProxyResource
@@ -194,6 +290,7 @@ type Handler struct {
 	etag           string
 	pwaResources   PreRenderCache
 	proxyResources map[string]ProxyResource
+	stateSync      *stateSyncServer
 }
 
 func (h *Handler) init() {
@@ -209,6 +306,7 @@ func (h *Handler) init() {
 	h.initPageContent()
 	h.initPreRenderedResources()
 	h.initProxyResources()
+	h.initStateSync()
 }
 
 func (h *Handler) initVersion() {
@@ -343,6 +441,10 @@ func (h *Handler) initPreRenderedResources() {
 		Body:        []byte(appCSS),
 	})
 
+	if h.PreRenderCacheKey == nil {
+		h.PreRenderCacheKey = h.PreRenderCacheVariants.Key
+	}
+
 	if h.PreRenderCache == nil {
 		h.PreRenderCache = NewPreRenderLRUCache(
 			defaultPreRenderCacheSize,
@@ -360,6 +462,11 @@ func (h *Handler) makeAppJS() []byte {
 	h.Env["GOAPP_VERSION"] = h.Version
 	h.Env["GOAPP_STATIC_RESOURCES_URL"] = h.Resources.Static()
 	h.Env["GOAPP_ROOT_PREFIX"] = h.Resources.Package()
+	h.Env["GOAPP_HYDRATE"] = strconv.FormatBool(h.Hydrate)
+	h.Env["GOAPP_SERVER_ACTIONS_URL"] = h.resolvePackagePath("/app-actions")
+	if h.SyncStates {
+		h.Env["GOAPP_STATE_SYNC_URL"] = h.resolvePackagePath("/app-states")
+	}
 
 	for k, v := range h.Env {
 		if err := os.Setenv(k, v); err != nil {
@@ -527,22 +634,27 @@ func (h *Handler) initProxyResources() {
 	h.proxyResources = resources
 }
 
+func (h *Handler) initStateSync() {
+	h.stateSync = newStateSyncServer(h.AuthorizeStateSync)
+
+	if h.SyncStates && h.AuthorizeStateSync == nil {
+		Log(errors.New("synced states are not served").
+			WithTag("reason", "AuthorizeStateSync is not set"))
+	}
+}
+
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.once.Do(h.init)
 
 	w.Header().Set("Cache-Control", "no-cache")
-	w.Header().Set("ETag", h.etag)
-
-	etag := r.Header.Get("If-None-Match")
-	if etag == h.etag {
-		w.WriteHeader(http.StatusNotModified)
-		return
-	}
 
 	path := r.URL.Path
 
 	fileHandler, isServingStaticResources := h.Resources.(http.Handler)
 	if isServingStaticResources && strings.HasPrefix(path, "/web/") {
+		if serveNotModified(w, r, h.etag) {
+			return
+		}
 		fileHandler.ServeHTTP(w, r)
 		return
 	}
@@ -555,6 +667,10 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		path = "/manifest.webmanifest"
 
 	case "/app.wasm", "/goapp.wasm":
+		if serveNotModified(w, r, h.etag) {
+			return
+		}
+
 		if isServingStaticResources {
 			r2 := *r
 			r2.URL.Path = h.Resources.AppWASM()
@@ -565,26 +681,194 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
 		return
 
+	case "/app-actions":
+		if h.router().hasServerActions() {
+			h.serveServerAction(w, r)
+			return
+		}
+
+	case "/app-states":
+		if h.SyncStates {
+			h.stateSync.ServeHTTP(w, r)
+			return
+		}
 	}
 
 	if res, ok := h.pwaResources.Get(r.Context(), path); ok {
+		if serveNotModified(w, r, h.etag) {
+			return
+		}
 		h.servePreRenderedItem(w, res)
 		return
 	}
 
-	if res, ok := h.PreRenderCache.Get(r.Context(), path); ok {
-		h.servePreRenderedItem(w, res)
+	if proxyResource, ok := h.proxyResources[path]; ok {
+		if serveNotModified(w, r, h.etag) {
+			return
+		}
+		if res, ok := h.PreRenderCache.Get(r.Context(), path); ok {
+			h.servePreRenderedItem(w, res)
+			return
+		}
+		h.serveProxyResource(proxyResource, w, r)
 		return
 	}
 
-	if proxyResource, ok := h.proxyResources[path]; ok {
-		h.serveProxyResource(proxyResource, w, r)
+	if vary := h.PreRenderCacheVariants.vary(); vary != "" {
+		w.Header().Set("Vary", vary)
+	}
+
+	// Pages of guarded routes are always rendered, which executes the route
+	// middlewares. Responding with a 304 status would let browsers display a
+	// cached page that the middlewares do not allow anymore.
+	if h.router().isGuarded(path) {
+		h.servePage(w, r)
 		return
 	}
 
+	cacheKey := h.PreRenderCacheKey(r)
+	if serveNotModified(w, r, h.pageETag(cacheKey)) {
+		return
+	}
+	if res, ok := h.PreRenderCache.Get(r.Context(), cacheKey); ok {
+		h.servePreRenderedItem(w, res)
+		return
+	}
 	h.servePage(w, r)
 }
 
+// pageETag returns the ETag of the page stored with the given pre-render cache
+// key. It changes with the handler version and with the key, so pages rendered
+// for other request attributes are not reused.
+func (h *Handler) pageETag(cacheKey string) string {
+	sum := sha1.Sum([]byte(cacheKey))
+	return fmt.Sprintf(`"%s-%x"`, h.Version, sum[:8])
+}
+
+// serveNotModified sets the given ETag and responds with a 304 status when the
+// request has the same one. It reports whether the response has been sent.
+func serveNotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") != etag {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+func (h *Handler) serveServerAction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		http.Error(w, "action must be encoded in json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	if r.Header.Get(serverActionHeader) == "" {
+		http.Error(w, "action must be called with CallServer", http.StatusForbidden)
+		return
+	}
+
+	var req struct {
+		Name  string
+		Value json.RawMessage
+		Tags  Tags
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, serverActionMaxBodySize)).Decode(&req); err != nil {
+		http.Error(w, "decoding action failed", http.StatusBadRequest)
+		return
+	}
+
+	handler, ok := h.router().getServerActionHandler(req.Name)
+	if !ok {
+		http.Error(w, "action has no server handler", http.StatusNotFound)
+		return
+	}
+
+	reply, err := handler(r, Action{
+		Name:  req.Name,
+		Value: req.Value,
+		Tags:  req.Tags,
+	})
+	if err != nil {
+		http.Error(w, "server action failed", http.StatusInternalServerError)
+		Log(errors.New("server action failed").
+			WithTag("action", req.Name).
+			Wrap(err))
+		return
+	}
+
+	body, err := json.Marshal(reply)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		Log(errors.New("encoding server action reply failed").
+			WithTag("action", req.Name).
+			Wrap(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// SetState sets the given state for all the clients. The state is pushed to the
+// connected clients and to the clients that connect later, where it is set with
+// the Sync option. It requires SyncStates to be enabled.
+func (h *Handler) SetState(state string, v any) error {
+	return h.SetClientState("", state, v)
+}
+
+// SetClientState sets the given state for the client with the given ID. The
+// client ID is the device ID returned by Context.DeviceID on the client side.
+// It requires SyncStates to be enabled.
+func (h *Handler) SetClientState(clientID, state string, v any) error {
+	h.once.Do(h.init)
+
+	if !h.SyncStates {
+		return errors.New("setting synced state failed").
+			WithTag("client", clientID).
+			WithTag("state", state).
+			WithTag("reason", "SyncStates is not enabled")
+	}
+
+	if err := h.stateSync.set(clientID, state, v); err != nil {
+		return errors.New("setting synced state failed").
+			WithTag("client", clientID).
+			WithTag("state", state).
+			Wrap(err)
+	}
+	return nil
+}
+
+// GetClientState stores the most recent value of the given state for the client
+// with the given ID into the given receiver. The receiver is set to its zero
+// value when the state is not set. It requires SyncStates to be enabled.
+func (h *Handler) GetClientState(clientID, state string, recv any) error {
+	h.once.Do(h.init)
+
+	if !h.SyncStates {
+		return errors.New("getting synced state failed").
+			WithTag("client", clientID).
+			WithTag("state", state).
+			WithTag("reason", "SyncStates is not enabled")
+	}
+
+	if err := h.stateSync.get(clientID, state, recv); err != nil {
+		return errors.New("getting synced state failed").
+			WithTag("client", clientID).
+			WithTag("state", state).
+			Wrap(err)
+	}
+	return nil
+}
+
 func (h *Handler) servePreRenderedItem(w http.ResponseWriter, i PreRenderedItem) {
 	w.Header().Set("Content-Length", strconv.Itoa(i.Size()))
 	w.Header().Set("Content-Type", i.ContentType)
@@ -597,7 +881,15 @@ func (h *Handler) servePreRenderedItem(w http.ResponseWriter, i PreRenderedItem)
 		w.Header().Set("Cache-Control", i.CacheControl)
 	}
 
-	w.WriteHeader(http.StatusOK)
+	for k, v := range i.Header {
+		w.Header()[k] = v
+	}
+
+	statusCode := i.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
 	w.Write(i.Body)
 }
 
@@ -656,12 +948,6 @@ func (h *Handler) serveProxyResource(resource ProxyResource, w http.ResponseWrit
 }
 
 func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
-	content, ok := routes.createComponent(r.URL.Path)
-	if !ok {
-		http.NotFound(w, r)
-		return
-	}
-
 	url := *r.URL
 	url.Host = r.Host
 	url.Scheme = "http"
@@ -680,7 +966,7 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 		Page:                   &page,
 		IsServerSide:           true,
 		StaticResourceResolver: h.resolveStaticPath,
-		ActionHandlers:         actionHandlers,
+		Router:                 h.router(),
 	}
 	body := h.Body().privateBody(Div())
 	if err := mount(&disp, body); err != nil {
@@ -693,116 +979,277 @@ func (h *Handler) servePage(w http.ResponseWriter, r *http.Request) {
 	disp.init()
 	defer disp.Close()
 
-	disp.Mount(Div().Body(
-		Aside().
+	req := RouteRequest{
+		URL:         page.url,
+		httpRequest: r,
+	}
+	content := h.router().handle(disp.Context(), &req)
+	if req.redirectURL != "" {
+		http.Redirect(w, r, req.redirectURL, http.StatusFound)
+		return
+	}
+	if content == nil {
+		http.NotFound(w, r)
+		return
+	}
+	cacheKey := h.PreRenderCacheKey(r)
+	if !req.isReplaced {
+		if res, ok := h.PreRenderCache.Get(r.Context(), cacheKey); ok {
+			h.servePreRenderedItem(w, res)
+			return
+		}
+	}
+
+	pageContent := func(states UI) UI {
+		loader := Aside().
 			ID("app-wasm-loader").
-			Class("goapp-app-info").
-			Body(
-				Img().
-					ID("app-wasm-loader-icon").
-					Class("goapp-logo goapp-spin").
-					Src(h.Icon.Default),
-				P().
-					ID("app-wasm-loader-label").
-					Class("goapp-label").
-					Text(page.loadingLabel),
-			),
-		Div().ID("app-pre-render").Body(content),
-	))
+			Class("goapp-app-info")
+		if h.Hydrate {
+			loader = loader.Style("display", "none")
+		}
+
+		return Div().Body(
+			loader.
+				Body(
+					Img().
+						ID("app-wasm-loader-icon").
+						Class("goapp-logo goapp-spin").
+						Src(h.Icon.Default),
+					P().
+						ID("app-wasm-loader-label").
+						Class("goapp-label").
+						Text(page.loadingLabel),
+				),
+			Div().ID("app-pre-render").Body(content),
+			states,
+		)
+	}
+	disp.Mount(pageContent(nil))
+
+	flusher, isStreaming := w.(http.Flusher)
+	isStreaming = isStreaming && h.StreamPreRendering
+
+	var streamedHead string
+	var streamedContent string
+	var statusCode int
+	var header http.Header
+	if isStreaming {
+		// OnPreRender handlers are executed before the status code and the
+		// head are sent, which lets them set the page response and info.
+		disp.consumeQueued()
+		statusCode = page.StatusCode()
+		header = page.header.Clone()
+		streamedHead = HTMLString(h.pageHead(&page))
+
+		w.Header().Set("Content-Type", "text/html")
+		if h.PreRenderCacheControl != "" {
+			w.Header().Set("Cache-Control", h.PreRenderCacheControl)
+		}
+		for k, v := range header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(statusCode)
+
+		io.WriteString(w, "<!DOCTYPE html>\n")
+		h.HTML().Lang(page.Lang()).(*htmlHtml).htmlOpeningTag(w)
+		io.WriteString(w, "\n")
+		io.WriteString(w, streamedHead)
+		io.WriteString(w, "\n")
+		body.(*htmlBody).htmlOpeningTag(w)
+		streamedContent = streamPreRenderedContent(w, body, "")
+		flusher.Flush()
+
+		// The content rendered by the goroutines launched with Async is
+		// streamed as they complete.
+		for disp.consumeNext() {
+			streamedContent = streamPreRenderedContent(w, body, streamedContent)
+			flusher.Flush()
+		}
+	}
 
 	for len(disp.dispatches) != 0 {
 		disp.Consume()
 		disp.Wait()
 	}
 
-	icon := h.Icon.SVG
-	if icon == "" {
-		icon = h.Icon.Default
+	// States set with the Transfer option are known once the pre-rendering
+	// dispatches are consumed. They are added next to the pre-rendered content
+	// so the client can seed its store before the first mount.
+	if states := disp.states.transferredStates(); len(states) != 0 {
+		disp.Mount(pageContent(transferredStatesScript(states)))
+		disp.Consume()
 	}
 
+	head := h.pageHead(&page)
 	var b bytes.Buffer
 	b.WriteString("<!DOCTYPE html>\n")
 	PrintHTML(&b, h.HTML().
 		Lang(page.Lang()).
 		privateBody(
-			Head().Body(
-				Meta().Charset("UTF-8"),
-				Meta().
-					Name("author").
-					Content(page.Author()),
-				Meta().
-					Name("description").
-					Content(page.Description()),
-				Meta().
-					Name("keywords").
-					Content(page.Keywords()),
-				Meta().
-					Name("theme-color").
-					Content(h.ThemeColor),
-				Meta().
-					Name("viewport").
-					Content("width=device-width, initial-scale=1, maximum-scale=1, user-scalable=0, viewport-fit=cover"),
-				Meta().
-					Property("og:url").
-					Content(page.URL().String()),
-				Meta().
-					Property("og:title").
-					Content(page.Title()),
-				Meta().
-					Property("og:description").
-					Content(page.Description()),
-				Meta().
-					Property("og:type").
-					Content("website"),
-				Meta().
-					Property("og:image").
-					Content(page.Image()),
-				Title().Text(page.Title()),
-				Link().
-					Rel("icon").
-					Href(icon),
-				Link().
-					Rel("apple-touch-icon").
-					Href(h.Icon.AppleTouch),
-				Link().
-					Rel("manifest").
-					Href(h.resolvePackagePath("/manifest.webmanifest")),
-				Link().
-					Type("text/css").
-					Rel("stylesheet").
-					Href(h.resolvePackagePath("/app.css")),
-				Script().
-					Defer(true).
-					Src(h.resolvePackagePath("/wasm_exec.js")),
-				Script().
-					Defer(true).
-					Src(h.resolvePackagePath("/app.js")),
-				Range(h.Styles).Slice(func(i int) UI {
-					return Link().
-						Type("text/css").
-						Rel("stylesheet").
-						Href(h.Styles[i])
-				}),
-				Range(h.Scripts).Slice(func(i int) UI {
-					return Script().
-						Defer(true).
-						Src(h.Scripts[i])
-				}),
-				Range(h.RawHeaders).Slice(func(i int) UI {
-					return Raw(h.RawHeaders[i])
-				}),
-			),
+			head,
 			body,
 		))
 
 	item := PreRenderedItem{
-		Path:         page.URL().Path,
-		Body:         b.Bytes(),
+		Path:         cacheKey,
 		ContentType:  "text/html",
 		CacheControl: h.PreRenderCacheControl,
+		StatusCode:   page.StatusCode(),
+		Header:       page.header,
+		Body:         b.Bytes(),
 	}
-	h.PreRenderCache.Set(r.Context(), item)
-	h.servePreRenderedItem(w, item)
+	isCacheable := !req.isReplaced && page.isCacheable()
+
+	if isStreaming {
+		streamPreRenderedContent(w, body, streamedContent)
+		io.WriteString(w, "\n")
+		body.(*htmlBody).htmlClosingTag(w)
+		io.WriteString(w, "\n</html>")
+		flusher.Flush()
+
+		// The response sent to the client cannot be changed once its head is
+		// streamed. Pages modified afterward are not cached in order to
+		// always serve the same response.
+		isCacheable = isCacheable &&
+			item.StatusCode == statusCode &&
+			reflect.DeepEqual(item.Header, header) &&
+			HTMLString(head) == streamedHead
+	}
+
+	if isCacheable {
+		h.PreRenderCache.Set(r.Context(), item)
+	}
+	if !isStreaming {
+		h.servePreRenderedItem(w, item)
+	}
+}
+
+// streamPreRenderedContent writes the body content when it differs from the
+// previously streamed one, and returns it. Content streamed after the first
+// one is written in a template, followed by a script that replaces the
+// previous content with it.
+func streamPreRenderedContent(w io.Writer, body UI, previous string) string {
+	content := HTMLString(body.getChildren()[0])
+	switch {
+	case content == previous:
+
+	case previous == "":
+		io.WriteString(w, "\n")
+		io.WriteString(w, content)
+
+	default:
+		io.WriteString(w, "\n<template id=\"app-pre-render-update\">")
+		io.WriteString(w, content)
+		io.WriteString(w, "</template>\n<script>")
+		io.WriteString(w, preRenderUpdateScript)
+		io.WriteString(w, "</script>")
+	}
+	return content
+}
+
+// preRenderUpdateScript replaces the streamed body content with the content
+// of the last streamed template, then removes the template and itself.
+const preRenderUpdateScript = `(function(){` +
+	`var t=document.getElementById("app-pre-render-update");` +
+	`document.body.firstElementChild.replaceWith(t.content);` +
+	`t.remove();` +
+	`document.currentScript.remove();` +
+	`})();`
+
+func transferredStatesScript(states map[string]json.RawMessage) UI {
+	b, err := json.Marshal(states)
+	if err != nil {
+		Log(errors.New("encoding transferred states failed").Wrap(err))
+		return nil
+	}
+
+	// JSON encoding escapes HTML characters, which makes the states safe to be
+	// embedded into a script element.
+	return Raw(`<script id="app-states" type="application/json">` +
+		string(b) +
+		`</script>`)
+}
+
+func (h *Handler) pageHead(page Page) UI {
+	icon := h.Icon.SVG
+	if icon == "" {
+		icon = h.Icon.Default
+	}
+
+	return Head().Body(
+		Meta().Charset("UTF-8"),
+		Meta().
+			Name("author").
+			Content(page.Author()),
+		Meta().
+			Name("description").
+			Content(page.Description()),
+		Meta().
+			Name("keywords").
+			Content(page.Keywords()),
+		Meta().
+			Name("theme-color").
+			Content(h.ThemeColor),
+		Meta().
+			Name("viewport").
+			Content("width=device-width, initial-scale=1, maximum-scale=1, user-scalable=0, viewport-fit=cover"),
+		Meta().
+			Property("og:url").
+			Content(page.URL().String()),
+		Meta().
+			Property("og:title").
+			Content(page.Title()),
+		Meta().
+			Property("og:description").
+			Content(page.Description()),
+		Meta().
+			Property("og:type").
+			Content("website"),
+		Meta().
+			Property("og:image").
+			Content(page.Image()),
+		Title().Text(page.Title()),
+		Link().
+			Rel("icon").
+			Href(icon),
+		Link().
+			Rel("apple-touch-icon").
+			Href(h.Icon.AppleTouch),
+		Link().
+			Rel("manifest").
+			Href(h.resolvePackagePath("/manifest.webmanifest")),
+		Link().
+			Type("text/css").
+			Rel("stylesheet").
+			Href(h.resolvePackagePath("/app.css")),
+		Script().
+			Defer(true).
+			Src(h.resolvePackagePath("/wasm_exec.js")),
+		Script().
+			Defer(true).
+			Src(h.resolvePackagePath("/app.js")),
+		Range(h.Styles).Slice(func(i int) UI {
+			return Link().
+				Type("text/css").
+				Rel("stylesheet").
+				Href(h.Styles[i])
+		}),
+		Range(h.Scripts).Slice(func(i int) UI {
+			return Script().
+				Defer(true).
+				Src(h.Scripts[i])
+		}),
+		Range(h.RawHeaders).Slice(func(i int) UI {
+			return Raw(h.RawHeaders[i])
+		}),
+	)
+}
+
+func (h *Handler) router() *Router {
+	if h.Router != nil {
+		return h.Router
+	}
+	return defaultRouter
 }
 
 func (h *Handler) resolvePackagePath(path string) string {
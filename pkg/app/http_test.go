@@ -4,12 +4,16 @@
 package app
 
 import (
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,6 +33,48 @@ func (c *preRenderTestCompo) Render() UI {
 		)
 }
 
+type preRenderResponseTestCompo struct {
+	Compo
+}
+
+func (c *preRenderResponseTestCompo) OnPreRender(ctx Context) {
+	ctx.Page().SetStatusCode(http.StatusNotFound)
+	ctx.Page().SetHeader("X-Test", "foo")
+}
+
+func (c *preRenderResponseTestCompo) Render() UI {
+	return Div().ID("pre-render-not-found")
+}
+
+type preRenderRedirectTestCompo struct {
+	Compo
+}
+
+func (c *preRenderRedirectTestCompo) OnPreRender(ctx Context) {
+	ctx.Page().Redirect("/login", http.StatusMovedPermanently)
+}
+
+func (c *preRenderRedirectTestCompo) Render() UI {
+	return Div()
+}
+
+type preRenderParamsTestCompo struct {
+	Compo
+
+	Name  string `route:"name"`
+	param string
+}
+
+func (c *preRenderParamsTestCompo) OnPreRender(ctx Context) {
+	c.param = ctx.Param("name")
+}
+
+func (c *preRenderParamsTestCompo) Render() UI {
+	return Div().
+		ID("pre-render-" + c.Name).
+		Text(c.param)
+}
+
 func TestHandlerServePageWithLocalDir(t *testing.T) {
 	r := httptest.NewRequest(http.MethodGet, "/", nil)
 	w := httptest.NewRecorder()
@@ -203,6 +249,572 @@ func TestHandlerServeAppJSWithRemoteBucket(t *testing.T) {
 	require.Contains(t, body, "GOAPP_VERSION")
 	require.Contains(t, body, `"GOAPP_STATIC_RESOURCES_URL":"https://storage.googleapis.com/go-app"`)
 	require.Contains(t, body, `"GOAPP_ROOT_PREFIX":""`)
+	require.Contains(t, body, `"GOAPP_HYDRATE":"false"`)
+}
+
+func TestHandlerServePageWithResponse(t *testing.T) {
+	router := NewRouter()
+	router.Route("/response-test", &preRenderResponseTestCompo{})
+
+	h := Handler{
+		Resources: LocalDir(""),
+		Router:    router,
+	}
+
+	for i := 0; i < 2; i++ {
+		r := httptest.NewRequest(http.MethodGet, "/response-test", nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.Equal(t, "foo", w.Header().Get("X-Test"))
+		require.Equal(t, "text/html", w.Header().Get("Content-Type"))
+		require.Contains(t, w.Body.String(), `<div id="pre-render-not-found">`)
+	}
+}
+
+func TestHandlerServePageWithRedirect(t *testing.T) {
+	router := NewRouter()
+	router.Route("/redirect-test", &preRenderRedirectTestCompo{})
+
+	h := Handler{
+		Resources: LocalDir(""),
+		Router:    router,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/redirect-test", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusMovedPermanently, w.Code)
+	require.Equal(t, "/login", w.Header().Get("Location"))
+}
+
+type preRenderStreamingTestCompo struct {
+	Compo
+}
+
+func (c *preRenderStreamingTestCompo) OnPreRender(ctx Context) {
+	ctx.Page().SetTitle("Streamed page")
+	ctx.Page().SetStatusCode(http.StatusNotFound)
+	ctx.Page().SetHeader("X-Test", "foo")
+	ctx.Page().AllowCaching()
+}
+
+func (c *preRenderStreamingTestCompo) Render() UI {
+	return Div().ID("pre-render-streamed")
+}
+
+func TestHandlerServePageWithStreaming(t *testing.T) {
+	router := NewRouter()
+	router.Route("/streaming-test", &preRenderStreamingTestCompo{})
+
+	h := Handler{
+		Resources:          LocalDir(""),
+		Title:              "Handler testing",
+		StreamPreRendering: true,
+		Router:             router,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/streaming-test", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	body := w.Body.String()
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.True(t, w.Flushed)
+	require.Equal(t, "text/html", w.Header().Get("Content-Type"))
+	require.Equal(t, "foo", w.Header().Get("X-Test"))
+	require.True(t, strings.HasPrefix(body, "<!DOCTYPE html>\n<html lang=\"en\">\n<head>"))
+	require.Contains(t, body, "<title>\nStreamed page\n</title>")
+	require.Contains(t, body, `<div id="pre-render-streamed">`)
+	require.True(t, strings.HasSuffix(body, "</body>\n</html>"))
+
+	_, isCached := h.PreRenderCache.Get(r.Context(), "/streaming-test")
+	require.True(t, isCached)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotFound, w.Code)
+	require.False(t, w.Flushed)
+	require.Equal(t, "foo", w.Header().Get("X-Test"))
+	require.Equal(t, body, w.Body.String())
+}
+
+type preRenderAsyncTestCompo struct {
+	Compo
+
+	isLoaded bool
+}
+
+func (c *preRenderAsyncTestCompo) OnPreRender(ctx Context) {
+	ctx.Page().AllowCaching()
+
+	ctx.Async(func() {
+		ctx.Dispatch(func(ctx Context) {
+			c.isLoaded = true
+			if ctx.Page().URL().Query().Get("status") != "" {
+				ctx.Page().SetStatusCode(http.StatusNotFound)
+			}
+		})
+	})
+}
+
+func (c *preRenderAsyncTestCompo) Render() UI {
+	if c.isLoaded {
+		return Div().ID("pre-render-loaded")
+	}
+	return Div().ID("pre-render-loading")
+}
+
+// flushRecorder is a response recorder that records the body written at each
+// flush.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+
+	flushes []string
+}
+
+func (r *flushRecorder) Flush() {
+	r.flushes = append(r.flushes, r.Body.String())
+	r.ResponseRecorder.Flush()
+}
+
+func TestHandlerServePageWithAsyncStreaming(t *testing.T) {
+	router := NewRouter()
+	router.Route("/async-streaming-test", &preRenderAsyncTestCompo{})
+
+	h := Handler{
+		Resources:          LocalDir(""),
+		StreamPreRendering: true,
+		Router:             router,
+	}
+
+	t.Run("content is streamed as it is rendered", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/async-streaming-test", nil)
+		w := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.True(t, len(w.flushes) >= 2)
+		require.Contains(t, w.flushes[0], `<div id="pre-render-loading">`)
+		require.NotContains(t, w.flushes[0], `<div id="pre-render-loaded">`)
+		require.Contains(t, w.flushes[1], `<template id="app-pre-render-update">`)
+		require.Contains(t, w.flushes[1], `<div id="pre-render-loaded">`)
+		require.Contains(t, w.flushes[1], preRenderUpdateScript)
+		require.True(t, strings.HasSuffix(w.Body.String(), "</body>\n</html>"))
+
+		item, isCached := h.PreRenderCache.Get(r.Context(), "/async-streaming-test")
+		require.True(t, isCached)
+		require.Equal(t, http.StatusOK, item.StatusCode)
+		require.Contains(t, string(item.Body), `<div id="pre-render-loaded">`)
+		require.NotContains(t, string(item.Body), `<div id="pre-render-loading">`)
+		require.NotContains(t, string(item.Body), "app-pre-render-update")
+	})
+
+	t.Run("page with status changed after streaming is not cached", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/async-streaming-test?status=404", nil)
+		w := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+		h.PreRenderCacheKey = func(r *http.Request) string { return r.URL.String() }
+		h.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		_, isCached := h.PreRenderCache.Get(r.Context(), r.URL.String())
+		require.False(t, isCached)
+	})
+}
+
+func TestHandlerServePageWithRouteParams(t *testing.T) {
+	router := NewRouter()
+	router.Route("/params-test/{name}", &preRenderParamsTestCompo{})
+
+	h := Handler{
+		Resources: LocalDir(""),
+		Router:    router,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/params-test/foo", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), "<div id=\"pre-render-foo\">\nfoo\n</div>")
+}
+
+func TestHandlerServePageWithRouteMiddleware(t *testing.T) {
+	router := NewRouter()
+	router.Route("/guard-test", &preRenderTestCompo{})
+	router.Use(func(ctx Context, r *RouteRequest) {
+		if r.Cookie("session") == "" {
+			r.Redirect("/login")
+		}
+	})
+
+	h := Handler{
+		Resources: LocalDir(""),
+		Router:    router,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/guard-test", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "42"})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `<div id="pre-render-ok">`)
+
+	_, isCached := h.PreRenderCache.Get(r.Context(), "/guard-test")
+	require.True(t, isCached)
+
+	r = httptest.NewRequest(http.MethodGet, "/guard-test", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusFound, w.Code)
+	require.Equal(t, "/login", w.Header().Get("Location"))
+
+	r = httptest.NewRequest(http.MethodGet, "/guard-test", nil)
+	r.Header.Set("If-None-Match", h.etag)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusFound, w.Code)
+	require.Empty(t, w.Header().Get("ETag"))
+
+	r = httptest.NewRequest(http.MethodGet, "/app.js", nil)
+	r.Header.Set("If-None-Match", h.etag)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotModified, w.Code)
+}
+
+type preRenderQueryTestCompo struct {
+	Compo
+
+	query string
+}
+
+func (c *preRenderQueryTestCompo) OnPreRender(ctx Context) {
+	c.query = ctx.Page().URL().Query().Get("q")
+	if c.query == "nocache" {
+		ctx.Page().PreventCaching()
+	}
+}
+
+func (c *preRenderQueryTestCompo) Render() UI {
+	return Div().ID("query-" + c.query)
+}
+
+func TestHandlerServePageWithCacheKey(t *testing.T) {
+	router := NewRouter()
+	router.Route("/search", &preRenderQueryTestCompo{})
+
+	h := Handler{
+		Resources:         LocalDir(""),
+		Router:            router,
+		PreRenderCacheKey: PreRenderCacheVariants{Query: true}.Key,
+	}
+
+	for _, q := range []string{"a", "b", "a"} {
+		r := httptest.NewRequest(http.MethodGet, "/search?q="+q, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `<div id="query-`+q+`">`)
+
+		_, isCached := h.PreRenderCache.Get(r.Context(), "/search?q="+q)
+		require.True(t, isCached)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/search?q=nocache", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `<div id="query-nocache">`)
+
+	_, isCached := h.PreRenderCache.Get(r.Context(), "/search?q=nocache")
+	require.False(t, isCached)
+}
+
+func TestHandlerServePageWithCacheVariants(t *testing.T) {
+	router := NewRouter()
+	router.Route("/variants", &preRenderTestCompo{})
+
+	h := Handler{
+		Resources: LocalDir(""),
+		Router:    router,
+		PreRenderCacheVariants: PreRenderCacheVariants{
+			Headers: []string{"accept-language"},
+			Cookies: []string{"theme"},
+		},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/variants", nil)
+	r.Header.Set("Accept-Language", "en")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "Accept-Language, Cookie", w.Header().Get("Vary"))
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	r = httptest.NewRequest(http.MethodGet, "/variants", nil)
+	r.Header.Set("Accept-Language", "en")
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotModified, w.Code)
+	require.Equal(t, "Accept-Language, Cookie", w.Header().Get("Vary"))
+
+	r = httptest.NewRequest(http.MethodGet, "/variants", nil)
+	r.Header.Set("Accept-Language", "fr")
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	r = httptest.NewRequest(http.MethodGet, "/variants", nil)
+	r.Header.Set("Accept-Language", "en")
+	r.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+}
+
+type preRenderCachingTestCompo struct {
+	Compo
+}
+
+func (c *preRenderCachingTestCompo) OnPreRender(ctx Context) {
+	page := ctx.Page()
+	query := page.URL().Query()
+
+	switch query.Get("case") {
+	case "redirect":
+		page.Redirect("/login", http.StatusFound)
+
+	case "not-found":
+		page.SetStatusCode(http.StatusNotFound)
+
+	case "header":
+		page.SetHeader("X-User", "maxence")
+
+	case "cookie":
+		page.SetHeader("Set-Cookie", "session=42")
+	}
+
+	if query.Get("allow") == "true" {
+		page.AllowCaching()
+	}
+}
+
+func (c *preRenderCachingTestCompo) Render() UI {
+	return Div()
+}
+
+func TestHandlerServePageCaching(t *testing.T) {
+	router := NewRouter()
+	router.Route("/caching", &preRenderCachingTestCompo{})
+
+	h := Handler{
+		Resources:         LocalDir(""),
+		Router:            router,
+		PreRenderCacheKey: PreRenderCacheVariants{Query: true}.Key,
+	}
+
+	utests := []struct {
+		scenario string
+		query    string
+		isCached bool
+	}{
+		{
+			scenario: "ok response is cached",
+			query:    "case=ok",
+			isCached: true,
+		},
+		{
+			scenario: "redirect is not cached",
+			query:    "case=redirect",
+		},
+		{
+			scenario: "non 2xx response is not cached",
+			query:    "case=not-found",
+		},
+		{
+			scenario: "response with header is not cached",
+			query:    "case=header",
+		},
+		{
+			scenario: "response with cookie is not cached",
+			query:    "case=cookie",
+		},
+		{
+			scenario: "allowed redirect is cached",
+			query:    "case=redirect&allow=true",
+			isCached: true,
+		},
+		{
+			scenario: "allowed non 2xx response is cached",
+			query:    "case=not-found&allow=true",
+			isCached: true,
+		},
+		{
+			scenario: "allowed response with header is cached",
+			query:    "case=header&allow=true",
+			isCached: true,
+		},
+		{
+			scenario: "allowed response with cookie is not cached",
+			query:    "case=cookie&allow=true",
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/caching?"+u.query, nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			_, isCached := h.PreRenderCache.Get(r.Context(), h.PreRenderCacheKey(r))
+			require.Equal(t, u.isCached, isCached)
+		})
+	}
+}
+
+type preRenderStatesTestCompo struct {
+	Compo
+}
+
+func (c *preRenderStatesTestCompo) OnPreRender(ctx Context) {
+	ctx.SetState("/transferred", "<b>hello</b>", Transfer)
+	ctx.SetState("/server-only", 42)
+}
+
+func (c *preRenderStatesTestCompo) Render() UI {
+	return Div()
+}
+
+func TestHandlerServePageWithTransferredStates(t *testing.T) {
+	router := NewRouter()
+	router.Route("/states", &preRenderStatesTestCompo{})
+	router.Route("/no-states", &preRenderQueryTestCompo{})
+
+	h := Handler{
+		Resources: LocalDir(""),
+		Router:    router,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/states", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body := w.Body.String()
+	require.Contains(t, body, `<script id="app-states" type="application/json">{"/transferred":"\u003cb\u003ehello\u003c/b\u003e"}</script>`)
+	require.NotContains(t, body, "/server-only")
+
+	r = httptest.NewRequest(http.MethodGet, "/no-states", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), "app-states")
+}
+
+func TestHandlerServePageWithRouter(t *testing.T) {
+	router := NewRouter()
+	router.Route("/params-test/{name}", &preRenderParamsTestCompo{})
+
+	h := Handler{
+		Resources: LocalDir(""),
+		Router:    router,
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	r = httptest.NewRequest(http.MethodGet, "/params-test/bar", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `<div id="pre-render-bar">`)
+}
+
+type preRenderAdminTestCompo struct {
+	Compo
+}
+
+func (c *preRenderAdminTestCompo) Render() UI {
+	return Div().ID("pre-render-admin")
+}
+
+func TestHandlerServePageWithRouters(t *testing.T) {
+	website := NewRouter()
+	website.Route("/", &preRenderTestCompo{})
+
+	admin := NewRouter()
+	admin.Route("/", &preRenderAdminTestCompo{})
+
+	mux := http.NewServeMux()
+	mux.Handle("/", &Handler{
+		Name:      "Website",
+		Resources: LocalDir(""),
+		Router:    website,
+	})
+	mux.Handle("/admin/", http.StripPrefix("/admin", &Handler{
+		Name:      "Admin",
+		Resources: CustomProvider("", "/admin"),
+		Router:    admin,
+		Env:       Environment{"FRONTEND": "admin"},
+	}))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("/")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `<div id="pre-render-ok">`)
+	require.Contains(t, w.Body.String(), `src="/app.js"`)
+
+	w = serve("/admin/")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `<div id="pre-render-admin">`)
+	require.Contains(t, w.Body.String(), `src="/admin/app.js"`)
+
+	w = serve("/admin/app.js")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"GOAPP_ROOT_PREFIX":"/admin"`)
+	require.Contains(t, w.Body.String(), `"FRONTEND":"admin"`)
+	require.Contains(t, w.Body.String(), `fetchWithProgress("/admin/web/app.wasm"`)
+
+	w = serve("/app.js")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotContains(t, w.Body.String(), `"FRONTEND":"admin"`)
+}
+
+func TestHandlerServePageWithHydrate(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	h := Handler{
+		Resources: LocalDir(""),
+		Hydrate:   true,
+	}
+	h.ServeHTTP(w, r)
+	body := w.Body.String()
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, body, `<aside class="goapp-app-info" id="app-wasm-loader" style="display:none;">`)
+	require.Contains(t, body, `<div id="pre-render-ok">`)
+
+	r = httptest.NewRequest(http.MethodGet, "/app.js", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	require.Contains(t, w.Body.String(), `"GOAPP_HYDRATE":"true"`)
 }
 
 func TestHandlerServeAppJSWithGitHubPages(t *testing.T) {
@@ -612,6 +1224,151 @@ func BenchmarkHandlerHotRun(b *testing.B) {
 	}
 }
 
+func TestHandlerServerActions(t *testing.T) {
+	r := NewRouter()
+	r.HandleServer("/test/greet", func(r *http.Request, a Action) (any, error) {
+		var name string
+		if err := a.DecodeValue(&name); err != nil {
+			return nil, err
+		}
+		return a.Tags.Get("greeting") + ", " + name + " from " + r.URL.Path, nil
+	})
+	r.HandleServer("/test/fail", func(r *http.Request, a Action) (any, error) {
+		return nil, errors.New("test failure")
+	})
+	r.HandleServer("/test/wait", func(r *http.Request, a Action) (any, error) {
+		<-r.Context().Done()
+		return nil, r.Context().Err()
+	})
+
+	s := httptest.NewServer(&Handler{
+		Resources: LocalDir(""),
+		Router:    r,
+	})
+	defer s.Close()
+
+	e := engine{
+		Router:           r,
+		ServerActionsURL: s.URL + "/app-actions",
+	}
+	e.init()
+	defer e.Close()
+
+	h := &hello{}
+	e.Mount(h)
+	e.Consume()
+	ctx := makeContext(h)
+
+	t.Run("server handler reply is received", func(t *testing.T) {
+		var reply string
+		var err error
+		CallServer(ctx, "/test/greet", "Maxence", time.Second, func(ctx Context, r string, replyErr error) {
+			reply = r
+			err = replyErr
+		}, T("greeting", "hello"))
+		e.Consume()
+		require.NoError(t, err)
+		require.Equal(t, "hello, Maxence from /app-actions", reply)
+	})
+
+	t.Run("untyped reply is json encoded", func(t *testing.T) {
+		var reply json.RawMessage
+		ctx.CallServer("/test/greet", "Maxence", time.Second, func(ctx Context, r json.RawMessage, replyErr error) {
+			reply = r
+		})
+		e.Consume()
+		require.Equal(t, `", Maxence from /app-actions"`, string(reply))
+	})
+
+	t.Run("server handler error is received", func(t *testing.T) {
+		var err error
+		ctx.CallServer("/test/fail", nil, time.Second, func(ctx Context, r json.RawMessage, replyErr error) {
+			err = replyErr
+		})
+		e.Consume()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "server action failed")
+		require.NotContains(t, err.Error(), "test failure")
+	})
+
+	t.Run("action without server handler returns an error", func(t *testing.T) {
+		var err error
+		ctx.CallServer("/test/unhandled", nil, time.Second, func(ctx Context, r json.RawMessage, replyErr error) {
+			err = replyErr
+		})
+		e.Consume()
+		require.Error(t, err)
+	})
+
+	t.Run("call is canceled when timeout is reached", func(t *testing.T) {
+		var err error
+		ctx.CallServer("/test/wait", nil, time.Millisecond*10, func(ctx Context, r json.RawMessage, replyErr error) {
+			err = replyErr
+		})
+		e.Consume()
+		require.Error(t, err)
+	})
+
+	t.Run("endpoint accepts only post requests", func(t *testing.T) {
+		res, err := http.Get(s.URL + "/app-actions")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	})
+
+	postAction := func(t *testing.T, contentType string, header bool, body string) int {
+		req, err := http.NewRequest(http.MethodPost, s.URL+"/app-actions", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		if header {
+			req.Header.Set(serverActionHeader, "1")
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		return res.StatusCode
+	}
+
+	t.Run("action with header is handled", func(t *testing.T) {
+		status := postAction(t, "application/json; charset=utf-8", true, `{"Name":"/test/greet","Value":"Max"}`)
+		require.Equal(t, http.StatusOK, status)
+	})
+
+	t.Run("action without json content type is rejected", func(t *testing.T) {
+		status := postAction(t, "text/plain", true, `{"Name":"/test/greet","Value":"Max"}`)
+		require.Equal(t, http.StatusUnsupportedMediaType, status)
+	})
+
+	t.Run("action without header is rejected", func(t *testing.T) {
+		status := postAction(t, "application/json", false, `{"Name":"/test/greet","Value":"Max"}`)
+		require.Equal(t, http.StatusForbidden, status)
+	})
+
+	t.Run("invalid action is rejected", func(t *testing.T) {
+		status := postAction(t, "application/json", true, "{")
+		require.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestHandlerServerActionsNotRegistered(t *testing.T) {
+	s := httptest.NewServer(&Handler{
+		Resources: LocalDir(""),
+		Router:    NewRouter(),
+	})
+	defer s.Close()
+
+	req, err := http.NewRequest(http.MethodPost, s.URL+"/app-actions", strings.NewReader(`{"Name":"/test/greet"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(serverActionHeader, "1")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
 func TestIsRemoteLocation(t *testing.T) {
 	tests := []struct {
 		scenario string
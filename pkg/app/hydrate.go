@@ -0,0 +1,128 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+const (
+	elementNode = 1
+	textNode    = 3
+)
+
+func hydrate(d Dispatcher, n UI, c *domCursor) error {
+	n.setSelf(n)
+	if n.Mounted() {
+		return errors.New("hydrating ui element failed").
+			WithTag("reason", "already mounted").
+			WithTag("name", n.name()).
+			WithTag("kind", n.Kind())
+	}
+	return n.hydrate(d, c)
+}
+
+// remount mounts the given element from scratch and puts it in place of the
+// pre-rendered nodes at the cursor position. It is used when the pre-rendered
+// markup does not match the element to hydrate. As many pre-rendered nodes as
+// the element renders are removed, which removes every node of a fragment or
+// a component with multiple roots.
+func remount(d Dispatcher, n UI, c *domCursor) error {
+	if err := n.mount(d); err != nil {
+		return err
+	}
+	c.insert(n)
+
+	for i := renderedNodeCount(n); i > 0; i-- {
+		c.skipFormatting()
+		if c.end() {
+			break
+		}
+		c.parent.removeChild(c.next())
+	}
+	return nil
+}
+
+// renderedNodeCount returns the number of nodes that the given element renders
+// in HTML. Fragment anchors are not counted since they are not pre-rendered.
+func renderedNodeCount(n UI) int {
+	switch n.Kind() {
+	case Component:
+		return renderedNodeCount(n.getChildren()[0])
+
+	case FragmentElem:
+		count := 0
+		for _, c := range n.getChildren() {
+			count += renderedNodeCount(c)
+		}
+		return count
+
+	default:
+		return 1
+	}
+}
+
+// domCursor iterates over the child nodes of a DOM element in order to attach
+// them to the UI elements that produced them during pre-rendering.
+type domCursor struct {
+	parent Value
+	node   Value
+}
+
+func newDOMCursor(parent Value) *domCursor {
+	return &domCursor{
+		parent: parent,
+		node:   parent.firstChild(),
+	}
+}
+
+func (c *domCursor) end() bool {
+	return c.node == nil || c.node.IsNull() || c.node.IsUndefined()
+}
+
+func (c *domCursor) next() Value {
+	node := c.node
+	c.node = node.nextSibling()
+	return node
+}
+
+func (c *domCursor) isElement(tag string) bool {
+	return !c.end() &&
+		c.node.nodeType() == elementNode &&
+		strings.EqualFold(c.node.tagName(), tag)
+}
+
+func (c *domCursor) isText() bool {
+	return !c.end() && c.node.nodeType() == textNode
+}
+
+// skipFormatting removes the whitespace text nodes that are written between
+// elements when a page is pre-rendered.
+func (c *domCursor) skipFormatting() {
+	for c.isText() && strings.TrimSpace(c.node.nodeValue()) == "" {
+		c.parent.removeChild(c.next())
+	}
+}
+
+func (c *domCursor) insert(n UI) {
+	if c.end() {
+		insertJSNodes(c.parent, n, nil)
+		return
+	}
+	insertJSNodes(c.parent, n, c.node)
+}
+
+func (c *domCursor) insertNode(v Value) {
+	if c.end() {
+		c.parent.appendChild(v)
+		return
+	}
+	c.parent.insertBefore(v, c.node)
+}
+
+// removeRemaining removes the nodes that have not been hydrated.
+func (c *domCursor) removeRemaining() {
+	for !c.end() {
+		c.parent.removeChild(c.next())
+	}
+}
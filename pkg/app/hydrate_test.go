@@ -0,0 +1,325 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHydrate(t *testing.T) {
+	t.Run("matching markup is reused", func(t *testing.T) {
+		h1 := testDOMElement("h1", testDOMText("hello"))
+		p := testDOMElement("p", testDOMText("world"))
+		div := testDOMElement("div",
+			testDOMText("\n  "),
+			h1,
+			testDOMText("\n  "),
+			p,
+			testDOMText("\n"),
+		)
+		root := testDOMElement("div", div)
+
+		n := Div().Body(
+			H1().Text("hello"),
+			P().Text("world"),
+		)
+		testHydrate(t, n, root)
+
+		require.Equal(t, div, n.JSValue())
+		require.Equal(t, []Value{h1, p}, div.children)
+		require.Equal(t, h1, n.getChildren()[0].JSValue())
+		require.Equal(t, h1.children[0], n.getChildren()[0].getChildren()[0].JSValue())
+		require.Equal(t, p, n.getChildren()[1].JSValue())
+	})
+
+	t.Run("text value is updated", func(t *testing.T) {
+		text := testDOMText("hello, world")
+		root := testDOMElement("div", testDOMElement("h1", text))
+
+		n := H1().Body(
+			Text("hello, "),
+			Text("world"),
+		)
+		testHydrate(t, n, root)
+
+		require.Equal(t, text, n.getChildren()[0].JSValue())
+		require.Equal(t, "hello, ", text.data)
+		require.Len(t, root.children[0].(*testDOMNode).children, 2)
+	})
+
+	t.Run("mismatching element is replaced", func(t *testing.T) {
+		span := testDOMElement("span")
+		p := testDOMElement("p")
+		root := testDOMElement("div", span, p)
+
+		n := Div().Body(
+			H1(),
+			P(),
+		)
+		d := NewClientTester(Div())
+		defer d.Close()
+
+		cursor := newDOMCursor(root)
+		for _, c := range n.getChildren() {
+			require.NoError(t, hydrate(d, c, cursor))
+		}
+
+		require.NotContains(t, root.children, span)
+		require.NotEqual(t, span, n.getChildren()[0].JSValue())
+		require.True(t, n.getChildren()[0].Mounted())
+		require.Equal(t, p, n.getChildren()[1].JSValue())
+	})
+
+	t.Run("remaining nodes are removed", func(t *testing.T) {
+		root := testDOMElement("div", testDOMElement("ul",
+			testDOMElement("li"),
+			testDOMElement("li"),
+		))
+
+		n := Ul().Body(
+			Li(),
+		)
+		testHydrate(t, n, root)
+
+		require.Len(t, root.children[0].(*testDOMNode).children, 1)
+	})
+
+	t.Run("component with multiple roots", func(t *testing.T) {
+		a := testDOMElement("li", testDOMText("a"))
+		b := testDOMElement("li", testDOMText("b"))
+		ul := testDOMElement("ul", a, testDOMText("\n"), b)
+		root := testDOMElement("div", ul)
+
+		n := Ul().Body(
+			&multiRootCompo{Items: []string{"a", "b"}},
+		)
+		testHydrate(t, n, root)
+
+		compo := n.getChildren()[0]
+		require.Equal(t, a, compo.JSValue())
+		require.Len(t, ul.children, 3)
+		require.Equal(t, a, ul.children[0])
+		require.Equal(t, b, ul.children[1])
+	})
+
+	t.Run("raw html", func(t *testing.T) {
+		svg := testDOMElement("svg")
+		root := testDOMElement("div", svg)
+
+		n := Raw("<svg></svg>")
+		testHydrate(t, n, root)
+
+		require.Equal(t, svg, n.JSValue())
+	})
+
+	t.Run("mounted element is not hydrated", func(t *testing.T) {
+		n := Div()
+		d := NewClientTester(n)
+		defer d.Close()
+		d.Consume()
+
+		err := hydrate(d, n, newDOMCursor(testDOMElement("div", testDOMElement("div"))))
+		require.Error(t, err)
+	})
+}
+
+func TestHydrateBody(t *testing.T) {
+	d := NewClientTester(Div())
+	defer d.Close()
+
+	a := testDOMElement("li", testDOMText("a"))
+	b := testDOMElement("li", testDOMText("b"))
+	c := testDOMElement("li", testDOMText("c"))
+	container := testDOMElement("div", a, testDOMText("\n"), b, testDOMText("\n"), c)
+	loader := testDOMElement("aside")
+	wrapper := testDOMElement("div", loader, container)
+	bodyNode := testDOMElement("body", wrapper)
+
+	body := Body().privateBody(Div()).(*htmlBody)
+	require.NoError(t, mount(d, body))
+	body.jsElement = bodyNode
+	body.children[0].(*htmlDiv).jsElement = wrapper
+
+	n := &multiRootCompo{Items: []string{"a", "b"}}
+	err := hydrateBody(d, body, n, container)
+	require.NoError(t, err)
+	d.Consume()
+	testMounted(t, n)
+
+	require.Equal(t, bodyNode, body.JSValue())
+	require.Equal(t, []UI{n}, body.getChildren())
+	require.Equal(t, body, n.getParent())
+
+	require.Equal(t, bodyNode, a.parent)
+	require.Equal(t, bodyNode, b.parent)
+	require.Equal(t, a, bodyNode.children[0])
+	require.Equal(t, b, bodyNode.children[1])
+	require.Equal(t, a, n.JSValue())
+	require.NotContains(t, bodyNode.children, wrapper)
+	require.Nil(t, wrapper.parent)
+	require.Nil(t, c.parent)
+}
+
+func TestRemount(t *testing.T) {
+	d := NewClientTester(Div())
+	defer d.Close()
+
+	spanA := testDOMElement("span")
+	spanB := testDOMElement("span")
+	p := testDOMElement("p")
+	root := testDOMElement("div", spanA, testDOMText("\n"), spanB, testDOMText("\n"), p)
+
+	n := Fragment(Li(), Li())
+	n.setSelf(n)
+	cursor := newDOMCursor(root)
+	require.NoError(t, remount(d, n, cursor))
+
+	require.NotContains(t, root.children, spanA)
+	require.NotContains(t, root.children, spanB)
+	require.Contains(t, root.children, p)
+
+	cursor.skipFormatting()
+	require.Equal(t, p, cursor.node)
+}
+
+func testHydrate(t *testing.T, n UI, root *testDOMNode) {
+	d := NewClientTester(Div())
+	defer d.Close()
+
+	err := hydrate(d, n, newDOMCursor(root))
+	require.NoError(t, err)
+	d.Consume()
+	testMounted(t, n)
+}
+
+// testDOMNode is a minimal in-memory DOM node used to test hydration.
+type testDOMNode struct {
+	value
+
+	parent   *testDOMNode
+	children []Value
+	typ      int
+	tag      string
+	data     string
+}
+
+func testDOMElement(tag string, children ...*testDOMNode) *testDOMNode {
+	n := &testDOMNode{
+		typ: elementNode,
+		tag: strings.ToUpper(tag),
+	}
+	for _, c := range children {
+		n.appendChild(c)
+	}
+	return n
+}
+
+func testDOMText(v string) *testDOMNode {
+	return &testDOMNode{
+		typ:  textNode,
+		data: v,
+	}
+}
+
+func (n *testDOMNode) IsNull() bool {
+	return false
+}
+
+func (n *testDOMNode) IsUndefined() bool {
+	return false
+}
+
+func (n *testDOMNode) Truthy() bool {
+	return true
+}
+
+func (n *testDOMNode) JSValue() Value {
+	return n
+}
+
+func (n *testDOMNode) firstChild() Value {
+	if len(n.children) == 0 {
+		return value{}
+	}
+	return n.children[0]
+}
+
+func (n *testDOMNode) nextSibling() Value {
+	if n.parent == nil {
+		return value{}
+	}
+
+	idx := n.parent.indexOf(n)
+	if idx < 0 || idx+1 >= len(n.parent.children) {
+		return value{}
+	}
+	return n.parent.children[idx+1]
+}
+
+func (n *testDOMNode) nodeType() int {
+	return n.typ
+}
+
+func (n *testDOMNode) tagName() string {
+	return n.tag
+}
+
+func (n *testDOMNode) nodeValue() string {
+	return n.data
+}
+
+func (n *testDOMNode) setNodeValue(v string) {
+	n.data = v
+}
+
+func (n *testDOMNode) appendChild(c Wrapper) {
+	n.insertBefore(c, nil)
+}
+
+func (n *testDOMNode) insertBefore(c, ref Wrapper) {
+	child := c.JSValue()
+	if node, ok := child.(*testDOMNode); ok {
+		if node.parent != nil {
+			node.parent.removeChild(node)
+		}
+		node.parent = n
+	}
+
+	idx := len(n.children)
+	if ref != nil {
+		if i := n.indexOf(ref.JSValue()); i >= 0 {
+			idx = i
+		}
+	}
+
+	n.children = append(n.children, nil)
+	copy(n.children[idx+1:], n.children[idx:])
+	n.children[idx] = child
+}
+
+func (n *testDOMNode) moveBefore(c, ref Wrapper) {
+	n.insertBefore(c, ref)
+}
+
+func (n *testDOMNode) removeChild(c Wrapper) {
+	child := c.JSValue()
+	idx := n.indexOf(child)
+	if idx < 0 {
+		return
+	}
+
+	if node, ok := child.(*testDOMNode); ok {
+		node.parent = nil
+	}
+	n.children = append(n.children[:idx], n.children[idx+1:]...)
+}
+
+func (n *testDOMNode) indexOf(v Value) int {
+	for i, c := range n.children {
+		if c == v {
+			return i
+		}
+	}
+	return -1
+}
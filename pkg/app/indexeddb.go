@@ -0,0 +1,298 @@
+package app
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+const (
+	indexedDBVersion     = 1
+	indexedDBObjectStore = "states"
+)
+
+// NewIndexedDBStorage returns a state storage that saves states into the
+// IndexedDB database with the given name.
+//
+// Values are loaded in memory asynchronously, when the storage is created.
+// Reads never wait for the database: persisted states are not found until they
+// are loaded, and their observers are notified once loading is finished.
+// Writes are batched and performed asynchronously once per animation frame,
+// which prevents persisting states from blocking the UI goroutine.
+//
+// Values are only kept in memory when IndexedDB is not available.
+func NewIndexedDBStorage(name string) StateStorage {
+	s := &indexedDBStorage{
+		name:         name,
+		values:       make(map[string][]byte),
+		pending:      make(map[string][]byte),
+		requestFlush: requestAnimationFrame,
+	}
+	s.open()
+	return s
+}
+
+type indexedDBStorage struct {
+	mutex            sync.Mutex
+	name             string
+	db               Value
+	values           map[string][]byte
+	pending          map[string][]byte
+	isLoaded         bool
+	isFlushScheduled bool
+	requestFlush     func(flush func())
+	loadHandler      func(keys []string)
+}
+
+func (s *indexedDBStorage) Set(k string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.values[k] = b
+	s.pending[k] = b
+	s.scheduleFlush()
+	return nil
+}
+
+func (s *indexedDBStorage) Get(k string, v any) error {
+	s.mutex.Lock()
+	b, ok := s.values[k]
+	s.mutex.Unlock()
+
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(b, v)
+}
+
+func (s *indexedDBStorage) Del(k string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.values, k)
+	s.pending[k] = nil
+	s.scheduleFlush()
+}
+
+func (s *indexedDBStorage) keys() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	keys := make([]string, 0, len(s.values))
+	for k := range s.values {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (s *indexedDBStorage) onLoad(h func(keys []string)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.loadHandler = h
+}
+
+func (s *indexedDBStorage) open() {
+	indexedDB := Window().Get("indexedDB")
+	if !indexedDB.Truthy() {
+		s.setLoaded(nil, nil)
+		return
+	}
+
+	req := indexedDB.Call("open", s.name, indexedDBVersion)
+
+	var onUpgradeNeeded, onSuccess, onError Func
+	release := func() {
+		onUpgradeNeeded.Release()
+		onSuccess.Release()
+		onError.Release()
+	}
+
+	onUpgradeNeeded = FuncOf(func(this Value, args []Value) any {
+		req.Get("result").Call("createObjectStore", indexedDBObjectStore)
+		return nil
+	})
+
+	onSuccess = FuncOf(func(this Value, args []Value) any {
+		release()
+		s.load(req.Get("result"))
+		return nil
+	})
+
+	onError = FuncOf(func(this Value, args []Value) any {
+		release()
+		Log(errors.New("opening indexeddb database failed").
+			WithTag("database", s.name).
+			WithTag("error", req.Get("error").Get("message").String()))
+		s.setLoaded(nil, nil)
+		return nil
+	})
+
+	req.Set("onupgradeneeded", onUpgradeNeeded)
+	req.Set("onsuccess", onSuccess)
+	req.Set("onerror", onError)
+}
+
+func (s *indexedDBStorage) load(db Value) {
+	req := db.
+		Call("transaction", indexedDBObjectStore, "readonly").
+		Call("objectStore", indexedDBObjectStore).
+		Call("openCursor")
+	values := make(map[string][]byte)
+
+	var onSuccess, onError Func
+	release := func() {
+		onSuccess.Release()
+		onError.Release()
+	}
+
+	onSuccess = FuncOf(func(this Value, args []Value) any {
+		cursor := req.Get("result")
+		if !cursor.Truthy() {
+			release()
+			s.setLoaded(db, values)
+			return nil
+		}
+
+		values[cursor.Get("key").String()] = []byte(cursor.Get("value").String())
+		cursor.Call("continue")
+		return nil
+	})
+
+	onError = FuncOf(func(this Value, args []Value) any {
+		release()
+		Log(errors.New("loading indexeddb states failed").
+			WithTag("database", s.name).
+			WithTag("error", req.Get("error").Get("message").String()))
+		s.setLoaded(db, nil)
+		return nil
+	})
+
+	req.Set("onsuccess", onSuccess)
+	req.Set("onerror", onError)
+}
+
+// setLoaded adds the loaded values to the storage and reports their keys to
+// the load handler. Values that were set or deleted while the database was
+// loading are not overwritten and are written into the database.
+func (s *indexedDBStorage) setLoaded(db Value, values map[string][]byte) {
+	s.mutex.Lock()
+	keys := make([]string, 0, len(values))
+	for k, b := range values {
+		if _, ok := s.pending[k]; !ok {
+			s.values[k] = b
+			keys = append(keys, k)
+		}
+	}
+	s.db = db
+	s.isLoaded = true
+	hasPendingWrites := len(s.pending) != 0
+	loadHandler := s.loadHandler
+	s.mutex.Unlock()
+
+	if hasPendingWrites {
+		s.flush()
+	}
+	if loadHandler != nil && len(keys) != 0 {
+		loadHandler(keys)
+	}
+}
+
+func (s *indexedDBStorage) scheduleFlush() {
+	if s.isFlushScheduled {
+		return
+	}
+	s.isFlushScheduled = true
+	s.requestFlush(s.flush)
+}
+
+// flush writes the values that were set or deleted since the previous flush
+// into the database, within a single transaction.
+func (s *indexedDBStorage) flush() {
+	s.mutex.Lock()
+	s.isFlushScheduled = false
+	if !s.isLoaded {
+		s.mutex.Unlock()
+		return
+	}
+	batch := s.pending
+	s.pending = make(map[string][]byte)
+	db := s.db
+	s.mutex.Unlock()
+
+	if db == nil || len(batch) == 0 {
+		return
+	}
+
+	if err := s.write(db, batch); err != nil {
+		Log(errors.New("writing indexeddb states failed").
+			WithTag("database", s.name).
+			Wrap(err))
+	}
+}
+
+func (s *indexedDBStorage) write(db Value, batch map[string][]byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Newf("%v", r)
+		}
+	}()
+
+	tx := db.Call("transaction", indexedDBObjectStore, "readwrite")
+	store := tx.Call("objectStore", indexedDBObjectStore)
+	for k, b := range batch {
+		if b == nil {
+			store.Call("delete", k)
+			continue
+		}
+		store.Call("put", string(b), k)
+	}
+
+	var onComplete, onError Func
+	release := func() {
+		onComplete.Release()
+		onError.Release()
+	}
+
+	onComplete = FuncOf(func(this Value, args []Value) any {
+		release()
+		return nil
+	})
+
+	onError = FuncOf(func(this Value, args []Value) any {
+		release()
+		Log(errors.New("writing indexeddb states failed").
+			WithTag("database", s.name).
+			WithTag("error", tx.Get("error").Get("message").String()))
+		return nil
+	})
+
+	tx.Set("oncomplete", onComplete)
+	tx.Set("onerror", onError)
+	return nil
+}
+
+// requestAnimationFrame calls the given function before the next repaint of the
+// page. The function is called from another goroutine when the page is not
+// repainted, such as outside of a web browser or when the page is hidden.
+func requestAnimationFrame(fn func()) {
+	if !Window().Get("requestAnimationFrame").Truthy() ||
+		Window().Get("document").Get("hidden").Bool() {
+		go fn()
+		return
+	}
+
+	var callback Func
+	callback = FuncOf(func(this Value, args []Value) any {
+		callback.Release()
+		fn()
+		return nil
+	})
+	Window().Call("requestAnimationFrame", callback)
+}
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexedDBStorage(t *testing.T) {
+	s := NewIndexedDBStorage("goapp-test").(*indexedDBStorage)
+
+	var flushes []func()
+	s.requestFlush = func(flush func()) {
+		flushes = append(flushes, flush)
+	}
+
+	t.Run("value is set and get", func(t *testing.T) {
+		var v int
+		err := s.Set("/a", 42)
+		require.NoError(t, err)
+
+		err = s.Get("/a", &v)
+		require.NoError(t, err)
+		require.Equal(t, 42, v)
+	})
+
+	t.Run("value is deleted", func(t *testing.T) {
+		var v int
+		s.Del("/a")
+
+		err := s.Get("/a", &v)
+		require.NoError(t, err)
+		require.Zero(t, v)
+	})
+
+	t.Run("value with non pointer receiver is not get", func(t *testing.T) {
+		err := s.Set("/b", 21)
+		require.NoError(t, err)
+
+		var v int
+		err = s.Get("/b", v)
+		require.Error(t, err)
+	})
+
+	t.Run("value that cannot be encoded is not set", func(t *testing.T) {
+		err := s.Set("/c", func() {})
+		require.Error(t, err)
+	})
+
+	t.Run("writes are batched", func(t *testing.T) {
+		s.flush()
+		flushes = nil
+		require.Empty(t, s.pending)
+
+		s.Set("/d", 1)
+		s.Set("/d", 2)
+		s.Set("/e", 3)
+		s.Del("/e")
+
+		require.True(t, s.isFlushScheduled)
+		require.Len(t, flushes, 1)
+		require.Len(t, s.pending, 2)
+		require.Equal(t, []byte("2"), s.pending["/d"])
+		require.Nil(t, s.pending["/e"])
+
+		flushes[0]()
+		require.False(t, s.isFlushScheduled)
+		require.Empty(t, s.pending)
+	})
+}
+
+func TestIndexedDBStorageLoad(t *testing.T) {
+	s := &indexedDBStorage{
+		values:       make(map[string][]byte),
+		pending:      make(map[string][]byte),
+		requestFlush: func(func()) {},
+	}
+
+	var loadedKeys []string
+	s.onLoad(func(keys []string) {
+		loadedKeys = keys
+	})
+
+	var v string
+	s.Set("/set", "new")
+	s.Del("/deleted")
+	s.Get("/loaded", &v)
+	require.Empty(t, v)
+
+	s.setLoaded(nil, map[string][]byte{
+		"/set":     []byte(`"old"`),
+		"/deleted": []byte(`"old"`),
+		"/loaded":  []byte(`"loaded"`),
+	})
+
+	require.Equal(t, []string{"/loaded"}, loadedKeys)
+
+	s.Get("/set", &v)
+	require.Equal(t, "new", v)
+
+	v = ""
+	s.Get("/deleted", &v)
+	require.Empty(t, v)
+
+	s.Get("/loaded", &v)
+	require.Equal(t, "loaded", v)
+}
+
+func TestStoreIndexedDBStorageLoad(t *testing.T) {
+	storage := &indexedDBStorage{
+		values:       make(map[string][]byte),
+		pending:      make(map[string][]byte),
+		requestFlush: func(func()) {},
+	}
+
+	bar := &bar{}
+	d := &engine{StateStorage: storage}
+	d.init()
+	defer d.Close()
+	d.Mount(bar)
+	d.Consume()
+
+	s := newStore(d)
+	defer s.Close()
+	key := "/test/store/indexeddb"
+
+	var v int
+	s.Observe(key, bar).Value(&v)
+	require.Zero(t, v)
+
+	var changes []StateChange
+	s.ObservePattern("/test/store/*", bar).OnChange(func(c StateChange) {
+		changes = append(changes, c)
+	})
+
+	storage.setLoaded(nil, map[string][]byte{
+		key:            []byte(`{"Value":42}`),
+		"/not/a/state": []byte(`"value"`),
+	})
+	d.Consume()
+	require.Equal(t, 42, v)
+	require.Len(t, changes, 1)
+	require.Equal(t, key, changes[0].State)
+}
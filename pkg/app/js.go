@@ -130,8 +130,14 @@ type Value interface {
 	setAttr(k, v string)
 	delAttr(k string)
 	firstChild() Value
+	nextSibling() Value
+	nodeType() int
+	tagName() string
+	nodeValue() string
 	appendChild(c Wrapper)
 	replaceChild(new, old Wrapper)
+	insertBefore(new, ref Wrapper)
+	moveBefore(new, ref Wrapper)
 	removeChild(c Wrapper)
 	firstElementChild() Value
 	addEventListener(event string, fn Func)
@@ -117,12 +117,34 @@ func (v value) firstChild() Value {
 	return value{}
 }
 
+func (v value) nextSibling() Value {
+	return value{}
+}
+
+func (v value) nodeType() int {
+	return 0
+}
+
+func (v value) tagName() string {
+	return ""
+}
+
+func (v value) nodeValue() string {
+	return ""
+}
+
 func (v value) appendChild(c Wrapper) {
 }
 
 func (v value) replaceChild(new, old Wrapper) {
 }
 
+func (v value) insertBefore(new, ref Wrapper) {
+}
+
+func (v value) moveBefore(new, ref Wrapper) {
+}
+
 func (v value) removeChild(c Wrapper) {
 }
 
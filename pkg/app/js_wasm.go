@@ -99,6 +99,22 @@ func (v value) firstChild() Value {
 	return v.Get("firstChild")
 }
 
+func (v value) nextSibling() Value {
+	return v.Get("nextSibling")
+}
+
+func (v value) nodeType() int {
+	return v.Get("nodeType").Int()
+}
+
+func (v value) tagName() string {
+	return v.Get("tagName").String()
+}
+
+func (v value) nodeValue() string {
+	return v.Get("nodeValue").String()
+}
+
 func (v value) appendChild(c Wrapper) {
 	v.Call("appendChild", c)
 }
@@ -107,6 +123,21 @@ func (v value) replaceChild(new, old Wrapper) {
 	v.Call("replaceChild", new, old)
 }
 
+func (v value) insertBefore(new, ref Wrapper) {
+	v.Call("insertBefore", new, ref)
+}
+
+// moveBefore moves the given node before the reference node with
+// Element.moveBefore when the browser supports it, which preserves the node
+// state such as focus and iframe documents. It falls back to insertBefore.
+func (v value) moveBefore(new, ref Wrapper) {
+	if v.Get("moveBefore").Truthy() {
+		v.Call("moveBefore", new, ref)
+		return
+	}
+	v.Call("insertBefore", new, ref)
+}
+
 func (v value) removeChild(c Wrapper) {
 	v.Call("removeChild", c)
 }
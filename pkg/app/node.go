@@ -4,7 +4,10 @@ import (
 	"context"
 	"io"
 	"reflect"
+	"sort"
 	"strings"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 )
 
 // UI is the interface that describes a user interface element such as
@@ -20,6 +23,7 @@ type UI interface {
 	Mounted() bool
 
 	name() string
+	getKey() string
 	self() UI
 	setSelf(UI)
 	getContext() context.Context
@@ -30,6 +34,7 @@ type UI interface {
 	setParent(UI)
 	getChildren() []UI
 	mount(Dispatcher) error
+	hydrate(Dispatcher, *domCursor) error
 	dismount()
 	canUpdateWith(UI) bool
 	updateWith(UI) error
@@ -58,6 +63,9 @@ func (k Kind) String() string {
 	case RawHTML:
 		return "raw"
 
+	case FragmentElem:
+		return "fragment"
+
 	default:
 		return "undefined"
 	}
@@ -82,11 +90,15 @@ const (
 
 	// RawHTML represents an HTML element obtained from a raw HTML code snippet.
 	RawHTML
+
+	// FragmentElem represents a group of sibling elements that are displayed
+	// without a wrapping HTML element.
+	FragmentElem
 )
 
 // FilterUIElems returns a filtered version of the given UI elements where
-// selector elements such as If and Range are interpreted and removed. It also
-// remove nil elements.
+// selector elements such as If and Range, and fragments are interpreted and
+// removed. It also remove nil elements.
 //
 // It should be used only when implementing components that can accept content
 // with variadic arguments like HTML elements Body method.
@@ -119,7 +131,7 @@ func FilterUIElems(v ...UI) []UI {
 		switch e.Kind() {
 		case SimpleText, HTML, Component, RawHTML:
 
-		case Selector:
+		case Selector, FragmentElem:
 			replaceAt(i, e.getChildren()...)
 
 		default:
@@ -130,6 +142,251 @@ func FilterUIElems(v ...UI) []UI {
 	return v
 }
 
+func updateChildren(parent UI, jsParent, end Value, children, newChildren []UI) ([]UI, error) {
+	if hasKeys(children) || hasKeys(newChildren) {
+		return updateKeyedChildren(parent, jsParent, end, children, newChildren)
+	}
+
+	i := 0
+	for ; i < len(children) && i < len(newChildren); i++ {
+		a := children[i]
+		b := newChildren[i]
+
+		if canUpdate(a, b) {
+			if err := update(a, b); err != nil {
+				return children, errors.New("updating child failed").
+					WithTag("child", reflect.TypeOf(a)).
+					WithTag("new-child", reflect.TypeOf(b)).
+					WithTag("index", i).
+					Wrap(err)
+			}
+			continue
+		}
+
+		if err := replace(parent, jsParent, a, b); err != nil {
+			return children, errors.New("replacing child failed").
+				WithTag("child", reflect.TypeOf(a)).
+				WithTag("new-child", reflect.TypeOf(b)).
+				WithTag("index", i).
+				Wrap(err)
+		}
+		children[i] = b
+	}
+
+	for j := i; j < len(children); j++ {
+		removeJSNodes(jsParent, children[j])
+		dismount(children[j])
+		children[j] = nil
+	}
+	if i < len(children) {
+		children = children[:i]
+	}
+
+	for ; i < len(newChildren); i++ {
+		b := newChildren[i]
+
+		if err := mount(parent.getDispatcher(), b); err != nil {
+			return children, errors.New("appending child failed").
+				WithTag("child", reflect.TypeOf(b)).
+				WithTag("index", i).
+				Wrap(err)
+		}
+
+		b.setParent(parent)
+		insertJSNodes(jsParent, b, end)
+		children = append(children, b)
+	}
+
+	return children, nil
+}
+
+// updateKeyedChildren updates the given children with the new ones by matching
+// them with their key. Matched children are updated and moved, the others are
+// mounted or dismounted. Children that are part of the longest increasing
+// subsequence of their previous positions keep their place in the DOM, which
+// makes reordering move as few nodes as possible.
+func updateKeyedChildren(parent UI, jsParent, end Value, children, newChildren []UI) ([]UI, error) {
+	keyed := make(map[string]int, len(children))
+	var unkeyed []int
+	for i, c := range children {
+		if k := keyOf(c); k != "" {
+			if _, isDuplicate := keyed[k]; !isDuplicate {
+				keyed[k] = i
+				continue
+			}
+		}
+		unkeyed = append(unkeyed, i)
+	}
+
+	isKept := make([]bool, len(children))
+	positions := make([]int, len(newChildren))
+	updated := make([]UI, 0, len(newChildren))
+
+	for i, b := range newChildren {
+		idx := -1
+		if k := keyOf(b); k != "" {
+			if j, ok := keyed[k]; ok {
+				idx = j
+				delete(keyed, k)
+			}
+		} else if len(unkeyed) != 0 {
+			idx = unkeyed[0]
+			unkeyed = unkeyed[1:]
+		}
+
+		if idx >= 0 && canUpdate(children[idx], b) {
+			a := children[idx]
+			if err := update(a, b); err != nil {
+				return children, errors.New("updating child failed").
+					WithTag("child", reflect.TypeOf(a)).
+					WithTag("new-child", reflect.TypeOf(b)).
+					WithTag("key", b.getKey()).
+					WithTag("index", i).
+					Wrap(err)
+			}
+
+			isKept[idx] = true
+			positions[i] = idx
+			updated = append(updated, a)
+			continue
+		}
+
+		if err := mount(parent.getDispatcher(), b); err != nil {
+			return children, errors.New("mounting child failed").
+				WithTag("child", reflect.TypeOf(b)).
+				WithTag("key", b.getKey()).
+				WithTag("index", i).
+				Wrap(err)
+		}
+		b.setParent(parent)
+		positions[i] = -1
+		updated = append(updated, b)
+	}
+
+	for i, c := range children {
+		if !isKept[i] {
+			removeJSNodes(jsParent, c)
+			dismount(c)
+		}
+	}
+
+	isInPlace := longestIncreasingSubsequence(positions)
+	next := end
+	for i := len(updated) - 1; i >= 0; i-- {
+		c := updated[i]
+		if !isInPlace[i] {
+			insertJSNodes(jsParent, c, next)
+		}
+		next = firstJSNode(c)
+	}
+
+	return updated, nil
+}
+
+// longestIncreasingSubsequence reports which of the given values are part of
+// their longest increasing subsequence. Negative values are never part of it.
+func longestIncreasingSubsequence(v []int) []bool {
+	isInSequence := make([]bool, len(v))
+	previous := make([]int, len(v))
+	var tails []int
+
+	for i, n := range v {
+		if n < 0 {
+			continue
+		}
+
+		j := sort.Search(len(tails), func(j int) bool {
+			return v[tails[j]] >= n
+		})
+
+		previous[i] = -1
+		if j > 0 {
+			previous[i] = tails[j-1]
+		}
+
+		if j == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[j] = i
+		}
+	}
+
+	if len(tails) == 0 {
+		return isInSequence
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = previous[i] {
+		isInSequence[i] = true
+	}
+	return isInSequence
+}
+
+func replace(parent UI, jsParent Value, old, new UI) error {
+	if err := mount(parent.getDispatcher(), new); err != nil {
+		return err
+	}
+
+	new.setParent(parent)
+	insertJSNodes(jsParent, new, firstJSNode(old))
+	removeJSNodes(jsParent, old)
+	dismount(old)
+	return nil
+}
+
+func jsNodes(n UI) []Value {
+	switch n.Kind() {
+	case Component:
+		return jsNodes(n.getChildren()[0])
+
+	case FragmentElem:
+		var nodes []Value
+		for _, c := range n.getChildren() {
+			nodes = append(nodes, jsNodes(c)...)
+		}
+		return append(nodes, n.(*fragment).anchor)
+
+	default:
+		return []Value{n.JSValue()}
+	}
+}
+
+func firstJSNode(n UI) Value {
+	return jsNodes(n)[0]
+}
+
+func insertJSNodes(jsParent Value, n UI, before Value) {
+	for _, node := range jsNodes(n) {
+		if before == nil {
+			jsParent.appendChild(node)
+		} else {
+			jsParent.insertBefore(node, before)
+		}
+	}
+}
+
+func removeJSNodes(jsParent Value, n UI) {
+	for _, node := range jsNodes(n) {
+		jsParent.removeChild(node)
+	}
+}
+
+func getJSParent(n UI) Value {
+	for p := n.getParent(); p != nil; p = p.getParent() {
+		if p.Kind() == HTML {
+			return p.JSValue()
+		}
+	}
+	return nil
+}
+
+func hasKeys(v []UI) bool {
+	for _, n := range v {
+		if keyOf(n) != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func mount(d Dispatcher, n UI) error {
 	n.setSelf(n)
 	return n.mount(d)
@@ -152,6 +409,15 @@ func update(a, b UI) error {
 	return a.updateWith(b)
 }
 
+// keyOf returns the key of the given element. Unlike getKey, it does not
+// require the element to be bound to itself, and does not modify it.
+func keyOf(n UI) string {
+	if keyer, ok := n.(Keyer); ok {
+		return keyer.Key()
+	}
+	return n.getKey()
+}
+
 // HTMLString return an HTML string representation of the given UI element.
 func HTMLString(ui UI) string {
 	var w strings.Builder
@@ -32,6 +32,10 @@ func TestKindString(t *testing.T) {
 			kind:           Selector,
 			expectedString: "selector",
 		},
+		{
+			kind:           FragmentElem,
+			expectedString: "fragment",
+		},
 	}
 
 	for _, u := range utests {
@@ -187,3 +191,42 @@ func TestHTMLString(t *testing.T) {
 		})
 	}
 }
+
+func TestLongestIncreasingSubsequence(t *testing.T) {
+	utests := []struct {
+		scenario string
+		values   []int
+		expected []bool
+	}{
+		{
+			scenario: "empty",
+			expected: []bool{},
+		},
+		{
+			scenario: "sorted values",
+			values:   []int{0, 1, 2},
+			expected: []bool{true, true, true},
+		},
+		{
+			scenario: "reversed values",
+			values:   []int{2, 1, 0},
+			expected: []bool{false, false, true},
+		},
+		{
+			scenario: "moved value",
+			values:   []int{1, 2, 3, 0, 4},
+			expected: []bool{true, true, true, false, true},
+		},
+		{
+			scenario: "negative values are ignored",
+			values:   []int{-1, 0, -1, 2, 1},
+			expected: []bool{false, true, false, false, true},
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			require.Equal(t, u.expected, longestIncreasingSubsequence(u.values))
+		})
+	}
+}
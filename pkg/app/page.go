@@ -1,8 +1,11 @@
 package app
 
 import (
+	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 )
 
 // Page is the interface that describes a web page.
@@ -56,6 +59,43 @@ type Page interface {
 
 	// Returns the page width and height in px.
 	Size() (w int, h int)
+
+	// Returns the HTTP status code of the page response.
+	StatusCode() int
+
+	// Sets the HTTP status code of the page response.
+	//
+	// Only works when pre-rendering.
+	SetStatusCode(int)
+
+	// Sets an HTTP header of the page response.
+	//
+	// Only works when pre-rendering.
+	SetHeader(k, v string)
+
+	// Redirects to the given URL with the given HTTP status code. The status
+	// code is http.StatusFound when it is not a redirect one.
+	//
+	// When pre-rendering, the page response has the status code and a Location
+	// header that points to the URL. Otherwise, the app navigates to the URL
+	// and replaces the current page in the browser history.
+	Redirect(url string, code int)
+
+	// Prevents the page from being stored in the pre-render cache. It should be
+	// called when the page content depends on the request in a way that is not
+	// covered by the Handler pre-render cache key.
+	//
+	// Only works when pre-rendering.
+	PreventCaching()
+
+	// Allows the page to be stored in the pre-render cache when its response
+	// status code is not a 2xx one or when headers are set with SetHeader. By
+	// default, those responses are not cached because a cached response is
+	// served to every request with the same pre-render cache key. Responses
+	// with a Set-Cookie header are never cached.
+	//
+	// Only works when pre-rendering.
+	AllowCaching()
 }
 
 type requestPage struct {
@@ -69,6 +109,10 @@ type requestPage struct {
 	url          *url.URL
 	width        int
 	height       int
+	statusCode   int
+	header       http.Header
+	noCache      bool
+	allowCache   bool
 }
 
 func (p *requestPage) Title() string {
@@ -135,6 +179,52 @@ func (p *requestPage) Size() (width int, height int) {
 	return p.width, p.height
 }
 
+func (p *requestPage) StatusCode() int {
+	if p.statusCode == 0 {
+		return http.StatusOK
+	}
+	return p.statusCode
+}
+
+func (p *requestPage) SetStatusCode(v int) {
+	p.statusCode = v
+}
+
+func (p *requestPage) SetHeader(k, v string) {
+	if p.header == nil {
+		p.header = make(http.Header)
+	}
+	p.header.Set(k, v)
+}
+
+func (p *requestPage) Redirect(url string, code int) {
+	p.SetStatusCode(redirectStatusCode(code))
+	p.SetHeader("Location", url)
+}
+
+func (p *requestPage) PreventCaching() {
+	p.noCache = true
+}
+
+func (p *requestPage) AllowCaching() {
+	p.allowCache = true
+}
+
+// isCacheable reports whether the page response can be stored in the
+// pre-render cache.
+func (p *requestPage) isCacheable() bool {
+	switch {
+	case p.noCache, p.header.Get("Set-Cookie") != "":
+		return false
+
+	case p.allowCache:
+		return true
+	}
+
+	code := p.StatusCode()
+	return code >= 200 && code < 300 && len(p.header) == 0
+}
+
 type browserPage struct {
 	url        *url.URL
 	dispatcher Dispatcher
@@ -219,6 +309,43 @@ func (p browserPage) Size() (width int, height int) {
 	return Window().Size()
 }
 
+func (p browserPage) StatusCode() int {
+	return http.StatusOK
+}
+
+func (p browserPage) SetStatusCode(v int) {
+}
+
+func (p browserPage) SetHeader(k, v string) {
+}
+
+func (p browserPage) PreventCaching() {
+}
+
+func (p browserPage) AllowCaching() {
+}
+
+func (p browserPage) Redirect(rawURL string, code int) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		Log(errors.New("redirecting to URL failed").
+			WithTag("url", rawURL).
+			WithTag("code", code).
+			Wrap(err))
+		return
+	}
+
+	p.dispatcher.Dispatch(Dispatch{
+		Mode: Defer,
+		Function: func(ctx Context) {
+			navigateTo(p.dispatcher, u, false)
+			if !isExternalNavigation(u) {
+				Window().replaceHistory(u)
+			}
+		},
+	})
+}
+
 func (p browserPage) metaByName(v string) Value {
 	meta := Window().
 		Get("document").
@@ -254,3 +381,10 @@ func (p browserPage) metaByProperty(v string) Value {
 
 	return meta
 }
+
+func redirectStatusCode(v int) int {
+	if v < 300 || v > 399 {
+		return http.StatusFound
+	}
+	return v
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -15,6 +16,25 @@ func TestRequestPage(t *testing.T) {
 	})
 }
 
+func TestRequestPageResponse(t *testing.T) {
+	p := &requestPage{}
+	require.Equal(t, http.StatusOK, p.StatusCode())
+
+	p.SetStatusCode(http.StatusNotFound)
+	require.Equal(t, http.StatusNotFound, p.StatusCode())
+
+	p.SetHeader("X-Test", "foo")
+	require.Equal(t, "foo", p.header.Get("X-Test"))
+
+	p.Redirect("/login", http.StatusMovedPermanently)
+	require.Equal(t, http.StatusMovedPermanently, p.StatusCode())
+	require.Equal(t, "/login", p.header.Get("Location"))
+
+	p.Redirect("/home", 0)
+	require.Equal(t, http.StatusFound, p.StatusCode())
+	require.Equal(t, "/home", p.header.Get("Location"))
+}
+
 func TestBrowserPage(t *testing.T) {
 	testSkipNonWasm(t)
 
@@ -102,6 +102,10 @@ func (r rangeLoop) name() string {
 	return "range"
 }
 
+func (r rangeLoop) getKey() string {
+	return ""
+}
+
 func (r rangeLoop) self() UI {
 	return r
 }
@@ -142,6 +146,12 @@ func (r rangeLoop) mount(Dispatcher) error {
 		WithTag("kind", r.Kind())
 }
 
+func (r rangeLoop) hydrate(Dispatcher, *domCursor) error {
+	return errors.New("range loop is not hydratable").
+		WithTag("name", r.name()).
+		WithTag("kind", r.Kind())
+}
+
 func (r rangeLoop) dismount() {
 }
 
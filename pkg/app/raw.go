@@ -51,6 +51,10 @@ func (r *raw) name() string {
 	return "raw." + r.tag
 }
 
+func (r *raw) getKey() string {
+	return ""
+}
+
 func (r *raw) self() UI {
 	return r
 }
@@ -120,6 +124,17 @@ func (r *raw) mount(d Dispatcher) error {
 	return nil
 }
 
+func (r *raw) hydrate(d Dispatcher, cursor *domCursor) error {
+	cursor.skipFormatting()
+	if !cursor.isElement(r.tag) {
+		return remount(d, r, cursor)
+	}
+
+	r.disp = d
+	r.jsvalue = cursor.next()
+	return nil
+}
+
 func (r *raw) dismount() {
 	r.jsvalue = nil
 }
@@ -1,21 +1,54 @@
 package app
 
 import (
+	"net/http"
+	"net/url"
 	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
 )
 
 var (
-	routes = makeRouter()
+	defaultRouter = NewRouter()
 )
 
 // Route associates the type of the given component to the given path.
 //
+// Paths can contain named parameters between braces that each match a path
+// segment. A parameter can be followed by a type that the segment must
+// satisfy: int, uint, float or bool. The last parameter can be suffixed with
+// "..." to match the remaining of the path. Eg:
+//
+//	app.Route("/users/{id:int}/posts/{slug}", &post{})
+//	app.Route("/files/{path...}", &file{})
+//
+// Parameter values are returned by Context.Param and are set to the
+// exported component fields that have a route tag:
+//
+//	type post struct {
+//	    app.Compo
+//
+//	    UserID int    `route:"id"`
+//	    Slug   string `route:"slug"`
+//	}
+//
+// Paths without parameters take priority over paths with parameters, which
+// take priority over routes with regular expressions. Paths with parameters
+// are matched in registration order: when several of them match a path, the
+// first registered takes priority. Routing a path that is already registered
+// replaces its component and keeps its priority.
+//
 // When a page is requested and matches the route, a new instance of the given
 // component is created before being displayed.
+//
+// The route is registered on the default router.
 func Route(path string, c Composer) {
-	routes.route(path, c)
+	defaultRouter.Route(path, c)
 }
 
 // RouteWithRegexp associates the type of the given component to the given
@@ -25,30 +58,223 @@ func Route(path string, c Composer) {
 //
 // When a page is requested and matches the pattern, a new instance of the given
 // component is created before being displayed.
+//
+// The route is registered on the default router.
 func RouteWithRegexp(pattern string, c Composer) {
-	routes.routeWithRegexp(pattern, c)
+	defaultRouter.RouteWithRegexp(pattern, c)
 }
 
-type router struct {
-	mu               sync.RWMutex
-	routes           map[string]reflect.Type
-	routesWithRegexp []regexpRoute
+// RouteLayout associates the type of the given layout component to the routes
+// with a path that starts with the given path. The given path can contain
+// parameters like the ones used with Route.
+//
+// When a routed page is requested, the component associated with the route is
+// displayed within the layouts that match the page path, the outermost layout
+// being the one with the shortest path. Layouts display the component of the
+// nested level by calling Outlet in their Render method:
+//
+//	app.RouteLayout("/admin", &adminShell{})
+//	app.Route("/admin/users", &users{})
+//
+//	func (s *adminShell) Render() app.UI {
+//	    return app.Div().Body(
+//	        app.Nav().Body(...),
+//	        app.Main().Body(app.Outlet(s)),
+//	    )
+//	}
+//
+// Layouts stay mounted when navigating between pages that share them. Only the
+// outlet content is updated or replaced, and each level receives its own OnNav
+// call.
+//
+// The layout is registered on the default router.
+func RouteLayout(path string, c Composer) {
+	defaultRouter.RouteLayout(path, c)
 }
 
-func makeRouter() router {
-	return router{
-		routes: make(map[string]reflect.Type),
+// Outlet returns the component of the nested route that the given layout
+// component displays. It returns an empty element when the layout is not
+// displayed by a route.
+func Outlet(layout Composer) UI {
+	if outlet := layout.getOutlet(); outlet != nil {
+		return outlet
 	}
+	return Fragment()
+}
+
+// RouteMiddleware is a function that is executed when a page is requested,
+// between the resolution of the route and the display of its component.
+//
+// Middlewares are executed on both server-side pre-rendering and client-side
+// navigation. They allow the navigation by doing nothing, redirect it with
+// RouteRequest.Redirect, or display another component with
+// RouteRequest.Replace.
+type RouteMiddleware func(ctx Context, r *RouteRequest)
+
+// UseRouteMiddleware registers the given middlewares. Middlewares are executed
+// in registration order until one redirects the navigation. Eg:
+//
+//	app.UseRouteMiddleware(func(ctx app.Context, r *app.RouteRequest) {
+//	    if strings.HasPrefix(r.URL.Path, "/admin") && r.Cookie("session") == "" {
+//	        r.Redirect("/login")
+//	    }
+//	})
+//
+// Pages that are guarded by middlewares are still served from the
+// pre-rendering cache, but only after the middlewares allow them.
+//
+// The middlewares are registered on the default router.
+func UseRouteMiddleware(m ...RouteMiddleware) {
+	defaultRouter.Use(m...)
+}
+
+// RouteRequest describes a page navigation that is processed by route
+// middlewares.
+type RouteRequest struct {
+	// The requested URL.
+	URL *url.URL
+
+	component   Composer
+	isReplaced  bool
+	redirectURL string
+	httpRequest *http.Request
 }
 
-func (r *router) route(path string, c Composer) {
+// Component returns the component that is displayed for the requested page.
+// It returns nil when the page is not routed.
+func (r *RouteRequest) Component() Composer {
+	return r.component
+}
+
+// Replace displays the given component instead of the routed one.
+func (r *RouteRequest) Replace(c Composer) {
+	r.component = c
+	r.isReplaced = true
+}
+
+// Redirect redirects the navigation to the given URL. The remaining
+// middlewares are not executed.
+func (r *RouteRequest) Redirect(url string) {
+	r.redirectURL = url
+}
+
+// Cookie returns the value of the named cookie. It returns an empty string
+// when the cookie does not exist.
+//
+// Percent-encoded characters are decoded, the same way when pre-rendering and
+// in the browser.
+func (r *RouteRequest) Cookie(name string) string {
+	if r.httpRequest != nil {
+		c, err := r.httpRequest.Cookie(name)
+		if err != nil {
+			return ""
+		}
+		return decodeCookieValue(c.Value)
+	}
+	return cookieValue(Window().Get("document").Get("cookie").String(), name)
+}
+
+// cookieValue returns the value of the named cookie from the given cookie
+// string, formatted like the document.cookie one.
+func cookieValue(cookies, name string) string {
+	for _, c := range strings.Split(cookies, ";") {
+		k, v, _ := strings.Cut(strings.TrimSpace(c), "=")
+		if k == name {
+			return decodeCookieValue(v)
+		}
+	}
+	return ""
+}
+
+// decodeCookieValue removes the double quotes around the given cookie value,
+// like net/http does, and decodes its percent-encoded characters. The value is
+// returned as it is when it is not validly encoded.
+func decodeCookieValue(v string) string {
+	if len(v) > 1 && v[0] == '"' && v[len(v)-1] == '"' {
+		v = v[1 : len(v)-1]
+	}
+	if dv, err := url.PathUnescape(v); err == nil {
+		return dv
+	}
+	return v
+}
+
+// Router is a set of routes, layouts, route middlewares and action handlers
+// that describes a go-app frontend.
+//
+// The package-level functions such as Route, RouteLayout or Handle register
+// into the default router, which is used when no router is specified. Routers
+// allow a single process to serve multiple frontends by attaching each of them
+// to its own Handler. A Handler that is not served from the root path has its
+// prefix stripped and uses resources that are prefixed the same way:
+//
+//	admin := app.NewRouter()
+//	admin.Route("/", &dashboard{})
+//
+//	http.Handle("/", &app.Handler{Name: "Website"})
+//	http.Handle("/admin/", http.StripPrefix("/admin", &app.Handler{
+//	    Name:      "Admin",
+//	    Router:    admin,
+//	    Resources: app.CustomProvider("", "/admin"),
+//	    Env:       app.Environment{"FRONTEND": "admin"},
+//	}))
+//
+// On the client, the WebAssembly app is started with the router RunWhenOnBrowser
+// method. When the frontends share the same WebAssembly binary, the router can
+// be picked with an environment variable set on the Handler:
+//
+//	if app.Getenv("FRONTEND") == "admin" {
+//	    admin.RunWhenOnBrowser()
+//	}
+//	app.RunWhenOnBrowser()
+type Router struct {
+	mu                sync.RWMutex
+	routes            map[string]reflect.Type
+	routesWithPattern []patternRoute
+	routesWithRegexp  []regexpRoute
+	layouts           []patternRoute
+	middlewares       []RouteMiddleware
+	actionHandlers    map[string]ActionHandler
+	actionMiddlewares []ActionMiddleware
+	serverActions     map[string]ServerActionHandler
+}
+
+// NewRouter creates an empty router.
+func NewRouter() *Router {
+	return &Router{
+		routes:         make(map[string]reflect.Type),
+		actionHandlers: make(map[string]ActionHandler),
+		serverActions:  make(map[string]ServerActionHandler),
+	}
+}
+
+// Route associates the type of the given component to the given path. See the
+// Route function for the path syntax.
+func (r *Router) Route(path string, c Composer) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if strings.Contains(path, "{") {
+		route := patternRoute{
+			pattern:   path,
+			segments:  parseRoutePattern(path),
+			compoType: reflect.TypeOf(c),
+		}
+		for i, pr := range r.routesWithPattern {
+			if pr.pattern == path {
+				r.routesWithPattern[i] = route
+				return
+			}
+		}
+		r.routesWithPattern = append(r.routesWithPattern, route)
+		return
+	}
 	r.routes[path] = reflect.TypeOf(c)
 }
 
-func (r *router) routeWithRegexp(pattern string, c Composer) {
+// RouteWithRegexp associates the type of the given component to the given
+// regular expression pattern.
+func (r *Router) RouteWithRegexp(pattern string, c Composer) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -58,33 +284,463 @@ func (r *router) routeWithRegexp(pattern string, c Composer) {
 	})
 }
 
-func (r *router) createComponent(path string) (Composer, bool) {
+// RouteLayout associates the type of the given layout component to the routes
+// with a path that starts with the given path. See the RouteLayout function
+// for more details.
+func (r *Router) RouteLayout(path string, c Composer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	layout := patternRoute{
+		pattern:   path,
+		segments:  parseRoutePattern(strings.TrimSuffix(path, "/")),
+		compoType: reflect.TypeOf(c),
+	}
+	for _, s := range layout.segments {
+		if s.isWildcard {
+			panic(errors.New("invalid layout path").
+				WithTag("path", path).
+				WithTag("reason", "layout path contains a wildcard parameter"))
+		}
+	}
+
+	for i, l := range r.layouts {
+		if l.pattern == path {
+			r.layouts[i] = layout
+			return
+		}
+	}
+	r.layouts = append(r.layouts, layout)
+
+	sort.SliceStable(r.layouts, func(a, b int) bool {
+		return len(r.layouts[a].segments) < len(r.layouts[b].segments)
+	})
+}
+
+// Use registers the given route middlewares.
+func (r *Router) Use(m ...RouteMiddleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.middlewares = append(r.middlewares, m...)
+}
+
+// Handle registers the handler for the given action name. When an action
+// occurs, the handler is executed on its own goroutine.
+func (r *Router) Handle(actionName string, h ActionHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.actionHandlers[actionName] = h
+}
+
+// HandleServer registers the server handler for the given action name. See
+// the HandleServer function for more details.
+func (r *Router) HandleServer(actionName string, h ServerActionHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.serverActions[actionName] = h
+}
+
+// UseActionMiddleware registers the given action middlewares. See the
+// UseActionMiddleware function for more details.
+func (r *Router) UseActionMiddleware(m ...ActionMiddleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.actionMiddlewares = append(r.actionMiddlewares, m...)
+}
+
+func (r *Router) getActionHandlers() map[string]ActionHandler {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	compoType, isRouted := r.routes[path]
-	if !isRouted {
-		for _, rwr := range r.routesWithRegexp {
-			if rwr.regexp.MatchString(path) {
-				compoType = rwr.compoType
-				isRouted = true
-				break
-			}
+	handlers := make(map[string]ActionHandler, len(r.actionHandlers))
+	for name, h := range r.actionHandlers {
+		handlers[name] = h
+	}
+	return handlers
+}
+
+func (r *Router) getServerActionHandler(actionName string) (ServerActionHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	h, ok := r.serverActions[actionName]
+	return h, ok
+}
+
+func (r *Router) hasServerActions() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.serverActions) != 0
+}
+
+func (r *Router) getActionMiddlewares() []ActionMiddleware {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]ActionMiddleware(nil), r.actionMiddlewares...)
+}
+
+func (r *Router) paths() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	paths := make([]string, 0, len(r.routes))
+	for path := range r.routes {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// isGuarded reports whether the given path is routed and has middlewares to
+// execute before being displayed.
+func (r *Router) isGuarded(path string) bool {
+	r.mu.RLock()
+	hasMiddlewares := len(r.middlewares) != 0
+	r.mu.RUnlock()
+
+	if !hasMiddlewares {
+		return false
+	}
+	_, _, isRouted := r.match(path)
+	return isRouted
+}
+
+// handle creates the component to display for the given request, wrapped
+// within its layouts, after executing the middlewares. It returns nil when
+// the page is not routed or when a middleware redirected the request.
+func (r *Router) handle(ctx Context, req *RouteRequest) Composer {
+	path := routePath(req.URL)
+	req.component, _ = r.createPage(path)
+
+	r.mu.RLock()
+	middlewares := r.middlewares
+	r.mu.RUnlock()
+
+	for _, m := range middlewares {
+		m(ctx, req)
+		if req.redirectURL != "" {
+			return nil
 		}
 	}
+
+	if req.component == nil {
+		return nil
+	}
+	return r.wrapLayouts(path, req.component)
+}
+
+func (r *Router) createComponent(path string) (Composer, bool) {
+	compo, isRouted := r.createPage(path)
+	if !isRouted {
+		return nil, false
+	}
+	return r.wrapLayouts(path, compo), true
+}
+
+func (r *Router) createPage(path string) (Composer, bool) {
+	compoType, params, isRouted := r.match(path)
 	if !isRouted {
 		return nil, false
 	}
+	return newRoutedComponent(compoType, params), true
+}
+
+func (r *Router) wrapLayouts(path string, compo Composer) Composer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for i := len(r.layouts) - 1; i >= 0; i-- {
+		l := r.layouts[i]
+		params, ok := l.matchPrefix(path)
+		if !ok {
+			continue
+		}
+
+		layout := newRoutedComponent(l.compoType, params)
+		layout.setOutlet(compo)
+		compo = layout
+	}
+
+	return compo
+}
+
+func (r *Router) params(path string) map[string]string {
+	_, params, _ := r.match(path)
+	return params
+}
+
+func (r *Router) match(path string) (reflect.Type, map[string]string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if compoType, isRouted := r.routes[path]; isRouted {
+		return compoType, nil, true
+	}
+
+	for _, pr := range r.routesWithPattern {
+		if params, ok := pr.match(path); ok {
+			return pr.compoType, params, true
+		}
+	}
 
-	compo := reflect.New(compoType.Elem()).Interface().(Composer)
-	return compo, true
+	for _, rwr := range r.routesWithRegexp {
+		if rwr.regexp.MatchString(path) {
+			return rwr.compoType, nil, true
+		}
+	}
+
+	return nil, nil, false
 }
 
-func (r *router) len() int {
-	return len(r.routes) + len(r.routesWithRegexp)
+func (r *Router) len() int {
+	return len(r.routes) + len(r.routesWithPattern) + len(r.routesWithRegexp)
 }
 
 type regexpRoute struct {
 	regexp    *regexp.Regexp
 	compoType reflect.Type
 }
+
+type patternRoute struct {
+	pattern   string
+	segments  []routeSegment
+	compoType reflect.Type
+}
+
+func (r patternRoute) match(path string) (map[string]string, bool) {
+	parts, ok := splitRoutePath(path)
+	if !ok {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, s := range r.segments {
+		if i >= len(parts) {
+			return nil, false
+		}
+
+		if s.isWildcard {
+			params[s.value] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+
+		if !s.match(parts[i], params) {
+			return nil, false
+		}
+	}
+
+	if len(parts) != len(r.segments) {
+		return nil, false
+	}
+	return params, true
+}
+
+func (r patternRoute) matchPrefix(path string) (map[string]string, bool) {
+	parts, ok := splitRoutePath(path)
+	if !ok || len(parts) < len(r.segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, s := range r.segments {
+		if !s.match(parts[i], params) {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func splitRoutePath(path string) ([]string, bool) {
+	if !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	return strings.Split(path[1:], "/"), true
+}
+
+type routeSegment struct {
+	value      string
+	isParam    bool
+	isWildcard bool
+	paramType  string
+}
+
+func parseRoutePattern(pattern string) []routeSegment {
+	if pattern == "" {
+		return nil
+	}
+
+	if !strings.HasPrefix(pattern, "/") {
+		panic(errors.New("invalid route pattern").
+			WithTag("pattern", pattern).
+			WithTag("reason", "pattern does not start with a slash"))
+	}
+
+	parts := strings.Split(pattern[1:], "/")
+	segments := make([]routeSegment, len(parts))
+	for i, p := range parts {
+		if !strings.HasPrefix(p, "{") || !strings.HasSuffix(p, "}") {
+			segments[i] = routeSegment{value: p}
+			continue
+		}
+
+		name := p[1 : len(p)-1]
+		s := routeSegment{isParam: true}
+
+		if strings.HasSuffix(name, "...") {
+			if i != len(parts)-1 {
+				panic(errors.New("invalid route pattern").
+					WithTag("pattern", pattern).
+					WithTag("reason", "wildcard parameter is not the last segment"))
+			}
+			name = strings.TrimSuffix(name, "...")
+			s.isWildcard = true
+		}
+
+		if idx := strings.IndexByte(name, ':'); idx >= 0 {
+			s.paramType = name[idx+1:]
+			name = name[:idx]
+		}
+
+		switch s.paramType {
+		case "", "int", "uint", "float", "bool":
+		default:
+			panic(errors.New("invalid route pattern").
+				WithTag("pattern", pattern).
+				WithTag("reason", "unknown parameter type").
+				WithTag("parameter", name).
+				WithTag("type", s.paramType))
+		}
+
+		if name == "" {
+			panic(errors.New("invalid route pattern").
+				WithTag("pattern", pattern).
+				WithTag("reason", "parameter has no name"))
+		}
+
+		s.value = name
+		segments[i] = s
+	}
+
+	return segments
+}
+
+func (s routeSegment) match(v string, params map[string]string) bool {
+	if !s.isParam {
+		return v == s.value
+	}
+
+	if v == "" || !s.accepts(v) {
+		return false
+	}
+	params[s.value] = v
+	return true
+}
+
+func (s routeSegment) accepts(v string) bool {
+	var err error
+	switch s.paramType {
+	case "int":
+		_, err = strconv.ParseInt(v, 10, 64)
+
+	case "uint":
+		_, err = strconv.ParseUint(v, 10, 64)
+
+	case "float":
+		_, err = strconv.ParseFloat(v, 64)
+
+	case "bool":
+		_, err = strconv.ParseBool(v)
+	}
+	return err == nil
+}
+
+func newRoutedComponent(t reflect.Type, params map[string]string) Composer {
+	compo := reflect.New(t.Elem()).Interface().(Composer)
+	injectRouteParams(compo, params)
+	return compo
+}
+
+func injectRouteParams(c Composer, params map[string]string) {
+	if len(params) == 0 {
+		return
+	}
+
+	v := reflect.ValueOf(c).Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := f.Tag.Lookup("route")
+		if !ok || !f.IsExported() {
+			continue
+		}
+
+		param, ok := params[name]
+		if !ok {
+			continue
+		}
+
+		if err := setRouteParam(v.Field(i), param); err != nil {
+			Log(errors.New("injecting route parameter failed").
+				WithTag("component", t).
+				WithTag("field", f.Name).
+				WithTag("parameter", name).
+				WithTag("value", param).
+				Wrap(err))
+		}
+	}
+}
+
+func setRouteParam(f reflect.Value, v string) error {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(v)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(v, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(v, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(v, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+
+	default:
+		return errors.New("unsupported field type").WithTag("type", f.Type())
+	}
+
+	return nil
+}
+
+// routePath returns the path of the given URL that is used to match routes.
+func routePath(u *url.URL) string {
+	path := strings.TrimPrefix(u.Path, rootPrefix)
+	if path == "" {
+		path = "/"
+	}
+	return path
+}
@@ -1,6 +1,9 @@
 package app
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 
@@ -18,9 +21,10 @@ type routeWithRegexpCompo struct {
 func TestRoutes(t *testing.T) {
 	utests := []struct {
 		scenario     string
-		createRoutes func(*router)
+		createRoutes func(*Router)
 		path         string
 		expected     Composer
+		params       map[string]string
 		notFound     bool
 	}{
 		{
@@ -35,8 +39,8 @@ func TestRoutes(t *testing.T) {
 		},
 		{
 			scenario: "path is routed",
-			createRoutes: func(r *router) {
-				r.route("/a", &routeCompo{})
+			createRoutes: func(r *Router) {
+				r.Route("/a", &routeCompo{})
 			},
 			expected: &routeCompo{},
 			path:     "/a",
@@ -44,76 +48,186 @@ func TestRoutes(t *testing.T) {
 		{
 			scenario: "path take priority over pattern",
 			path:     "/abc",
-			createRoutes: func(r *router) {
-				r.route("/abc", &routeCompo{})
-				r.routeWithRegexp("^/a.*$", &routeWithRegexpCompo{})
+			createRoutes: func(r *Router) {
+				r.Route("/abc", &routeCompo{})
+				r.RouteWithRegexp("^/a.*$", &routeWithRegexpCompo{})
 			},
 			expected: &routeCompo{},
 		},
 		{
 			scenario: "pattern is routed",
 			path:     "/ab",
-			createRoutes: func(r *router) {
-				r.route("/abc", &routeCompo{})
-				r.routeWithRegexp("^/a.*$", &routeWithRegexpCompo{})
+			createRoutes: func(r *Router) {
+				r.Route("/abc", &routeCompo{})
+				r.RouteWithRegexp("^/a.*$", &routeWithRegexpCompo{})
 			},
 			expected: &routeWithRegexpCompo{},
 		},
 		{
 			scenario: "pattern with inner wildcard is routed",
 			path:     "/user/42/settings",
-			createRoutes: func(r *router) {
-				r.routeWithRegexp("^/user/.*/settings$", &routeWithRegexpCompo{})
+			createRoutes: func(r *Router) {
+				r.RouteWithRegexp("^/user/.*/settings$", &routeWithRegexpCompo{})
 			},
 			expected: &routeWithRegexpCompo{},
 		},
 		{
 			scenario: "not matching pattern with inner wildcard is not routed",
 			path:     "/user/42/settings/",
-			createRoutes: func(r *router) {
-				r.routeWithRegexp("^/user/.*/settings$", &routeWithRegexpCompo{})
+			createRoutes: func(r *Router) {
+				r.RouteWithRegexp("^/user/.*/settings$", &routeWithRegexpCompo{})
 			},
 			notFound: true,
 		},
 		{
 			scenario: "pattern with end wildcard is routed",
 			path:     "/user/1001/files/foo/bar/baz.png",
-			createRoutes: func(r *router) {
-				r.routeWithRegexp("^/user/.*/files/.*$", &routeWithRegexpCompo{})
+			createRoutes: func(r *Router) {
+				r.RouteWithRegexp("^/user/.*/files/.*$", &routeWithRegexpCompo{})
 			},
 			expected: &routeWithRegexpCompo{},
 		},
 		{
 			scenario: "not matching pattern with end wildcard is not routed",
 			path:     "/user/1001/files",
-			createRoutes: func(r *router) {
-				r.routeWithRegexp("^/user/.*/files/.*$", &routeWithRegexpCompo{})
+			createRoutes: func(r *Router) {
+				r.RouteWithRegexp("^/user/.*/files/.*$", &routeWithRegexpCompo{})
 			},
 			notFound: true,
 		},
 		{
 			scenario: "pattern with OR condition is routed",
 			path:     "/color/red",
-			createRoutes: func(r *router) {
-				r.routeWithRegexp("^/color/(red|green|blue)$", &routeWithRegexpCompo{})
+			createRoutes: func(r *Router) {
+				r.RouteWithRegexp("^/color/(red|green|blue)$", &routeWithRegexpCompo{})
 			},
 			expected: &routeWithRegexpCompo{},
 		},
 		{
 			scenario: "not matching pattern with OR condition is not routed",
 			path:     "/color/fuschia",
-			createRoutes: func(r *router) {
-				r.routeWithRegexp("^/color/(red|green|blue)$", &routeWithRegexpCompo{})
+			createRoutes: func(r *Router) {
+				r.RouteWithRegexp("^/color/(red|green|blue)$", &routeWithRegexpCompo{})
 			},
 			notFound: true,
 		},
+		{
+			scenario: "path with parameters is routed",
+			path:     "/users/42/posts/hello-world",
+			createRoutes: func(r *Router) {
+				r.Route("/users/{id}/posts/{slug}", &routeCompo{})
+			},
+			expected: &routeCompo{},
+			params: map[string]string{
+				"id":   "42",
+				"slug": "hello-world",
+			},
+		},
+		{
+			scenario: "path with missing parameter is not routed",
+			path:     "/users/42/posts",
+			createRoutes: func(r *Router) {
+				r.Route("/users/{id}/posts/{slug}", &routeCompo{})
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with empty parameter is not routed",
+			path:     "/users//posts",
+			createRoutes: func(r *Router) {
+				r.Route("/users/{id}/posts", &routeCompo{})
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with extra segment is not routed",
+			path:     "/users/42/posts",
+			createRoutes: func(r *Router) {
+				r.Route("/users/{id}", &routeCompo{})
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with typed parameter is routed",
+			path:     "/users/42",
+			createRoutes: func(r *Router) {
+				r.Route("/users/{id:int}", &routeCompo{})
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"id": "42"},
+		},
+		{
+			scenario: "path with mismatching typed parameter is not routed",
+			path:     "/users/me",
+			createRoutes: func(r *Router) {
+				r.Route("/users/{id:int}", &routeCompo{})
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path with wildcard parameter is routed",
+			path:     "/files/foo/bar/baz.png",
+			createRoutes: func(r *Router) {
+				r.Route("/files/{path...}", &routeCompo{})
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"path": "foo/bar/baz.png"},
+		},
+		{
+			scenario: "path without wildcard segment is not routed",
+			path:     "/files",
+			createRoutes: func(r *Router) {
+				r.Route("/files/{path...}", &routeCompo{})
+			},
+			notFound: true,
+		},
+		{
+			scenario: "path take priority over path with parameters",
+			path:     "/users/me",
+			createRoutes: func(r *Router) {
+				r.Route("/users/{id}", &routeWithRegexpCompo{})
+				r.Route("/users/me", &routeCompo{})
+			},
+			expected: &routeCompo{},
+		},
+		{
+			scenario: "first registered path with parameters take priority",
+			path:     "/users/42",
+			createRoutes: func(r *Router) {
+				r.Route("/users/{name}", &routeCompo{})
+				r.Route("/users/{id:int}", &routeWithRegexpCompo{})
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"name": "42"},
+		},
+		{
+			scenario: "path with parameters registered again is replaced",
+			path:     "/users/42",
+			createRoutes: func(r *Router) {
+				r.Route("/users/{id}", &routeWithRegexpCompo{})
+				r.Route("/users/{name}", &routeWithRegexpCompo{})
+				r.Route("/users/{id}", &routeCompo{})
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"id": "42"},
+		},
+		{
+			scenario: "path with parameters take priority over pattern",
+			path:     "/users/42",
+			createRoutes: func(r *Router) {
+				r.RouteWithRegexp("^/users/.*$", &routeWithRegexpCompo{})
+				r.Route("/users/{id}", &routeCompo{})
+			},
+			expected: &routeCompo{},
+			params:   map[string]string{"id": "42"},
+		},
 	}
 
 	for _, u := range utests {
 		t.Run(u.scenario, func(t *testing.T) {
-			r := makeRouter()
+			r := NewRouter()
 			if u.createRoutes != nil {
-				u.createRoutes(&r)
+				u.createRoutes(r)
 			}
 
 			compo, isRouted := r.createComponent(u.path)
@@ -125,6 +239,283 @@ func TestRoutes(t *testing.T) {
 			require.True(t, isRouted)
 			require.NotNil(t, compo)
 			require.Equal(t, reflect.TypeOf(u.expected), reflect.TypeOf(compo))
+			require.Equal(t, u.params, r.params(u.path))
 		})
 	}
 }
+
+func TestRouteInvalidPattern(t *testing.T) {
+	utests := []struct {
+		scenario string
+		pattern  string
+	}{
+		{
+			scenario: "pattern without leading slash",
+			pattern:  "users/{id}",
+		},
+		{
+			scenario: "parameter without name",
+			pattern:  "/users/{}",
+		},
+		{
+			scenario: "parameter with unknown type",
+			pattern:  "/users/{id:uuid}",
+		},
+		{
+			scenario: "wildcard parameter is not the last segment",
+			pattern:  "/files/{path...}/info",
+		},
+	}
+
+	for _, u := range utests {
+		t.Run(u.scenario, func(t *testing.T) {
+			r := NewRouter()
+			require.Panics(t, func() {
+				r.Route(u.pattern, &routeCompo{})
+			})
+		})
+	}
+}
+
+type routeParamsCompo struct {
+	Compo
+
+	ID      int     `route:"id"`
+	Slug    string  `route:"slug"`
+	Score   float64 `route:"score"`
+	Enabled bool    `route:"enabled"`
+	Invalid int     `route:"slug"`
+	ignored string  `route:"slug"`
+}
+
+func TestRouteInjectParams(t *testing.T) {
+	r := NewRouter()
+	r.Route("/users/{id:int}/posts/{slug}/{score:float}/{enabled:bool}", &routeParamsCompo{})
+
+	compo, isRouted := r.createComponent("/users/42/posts/hello/4.2/true")
+	require.True(t, isRouted)
+
+	c := compo.(*routeParamsCompo)
+	require.Equal(t, 42, c.ID)
+	require.Equal(t, "hello", c.Slug)
+	require.Equal(t, 4.2, c.Score)
+	require.True(t, c.Enabled)
+	require.Zero(t, c.Invalid)
+	require.Empty(t, c.ignored)
+}
+
+type layoutCompo struct {
+	Compo
+
+	navCount int
+}
+
+func (l *layoutCompo) OnNav(ctx Context) {
+	l.navCount++
+}
+
+func (l *layoutCompo) Render() UI {
+	return Div().Body(
+		Outlet(l),
+	)
+}
+
+type nestedLayoutCompo struct {
+	Compo
+
+	ID       string `route:"id"`
+	navCount int
+}
+
+func (l *nestedLayoutCompo) OnNav(ctx Context) {
+	l.navCount++
+}
+
+func (l *nestedLayoutCompo) Render() UI {
+	return Section().Body(
+		Outlet(l),
+	)
+}
+
+type layoutPageCompo struct {
+	Compo
+
+	navCount int
+}
+
+func (p *layoutPageCompo) OnNav(ctx Context) {
+	p.navCount++
+}
+
+func (p *layoutPageCompo) Render() UI {
+	return Text("page")
+}
+
+func TestRouteLayouts(t *testing.T) {
+	r := NewRouter()
+	r.RouteLayout("/users/{id}/", &nestedLayoutCompo{})
+	r.RouteLayout("/", &layoutCompo{})
+	r.Route("/", &routeCompo{})
+	r.Route("/users/{id}/posts", &layoutPageCompo{})
+	r.Route("/users", &routeWithRegexpCompo{})
+
+	t.Run("root path is wrapped by root layout", func(t *testing.T) {
+		compo, isRouted := r.createComponent("/")
+		require.True(t, isRouted)
+		require.IsType(t, &layoutCompo{}, compo)
+		require.IsType(t, &routeCompo{}, compo.getOutlet())
+	})
+
+	t.Run("path is wrapped by nested layouts", func(t *testing.T) {
+		compo, isRouted := r.createComponent("/users/42/posts")
+		require.True(t, isRouted)
+		require.IsType(t, &layoutCompo{}, compo)
+
+		nested := compo.getOutlet()
+		require.IsType(t, &nestedLayoutCompo{}, nested)
+		require.Equal(t, "42", nested.(*nestedLayoutCompo).ID)
+		require.IsType(t, &layoutPageCompo{}, nested.getOutlet())
+	})
+
+	t.Run("path shorter than layout path is not wrapped", func(t *testing.T) {
+		compo, isRouted := r.createComponent("/users")
+		require.True(t, isRouted)
+		require.IsType(t, &routeWithRegexpCompo{}, compo.getOutlet())
+	})
+
+	t.Run("not routed path is not wrapped", func(t *testing.T) {
+		compo, isRouted := r.createComponent("/users/42/comments")
+		require.False(t, isRouted)
+		require.Nil(t, compo)
+	})
+
+	t.Run("invalid layout path", func(t *testing.T) {
+		require.Panics(t, func() {
+			r.RouteLayout("/files/{path...}", &layoutCompo{})
+		})
+	})
+}
+
+func TestRouteLayoutsNavigation(t *testing.T) {
+	r := NewRouter()
+	r.RouteLayout("/", &layoutCompo{})
+	r.RouteLayout("/users/{id}", &nestedLayoutCompo{})
+	r.Route("/", &routeCompo{})
+	r.Route("/users/{id}/posts", &layoutPageCompo{})
+
+	compo, _ := r.createComponent("/users/42/posts")
+	d := NewClientTester(compo)
+	defer d.Close()
+	d.Nav(&url.URL{Path: "/users/42/posts"})
+	d.Consume()
+
+	layout := compo.(*layoutCompo)
+	nested := layout.getOutlet().(*nestedLayoutCompo)
+	page := nested.getOutlet().(*layoutPageCompo)
+	require.Equal(t, 1, layout.navCount)
+	require.Equal(t, 1, nested.navCount)
+	require.Equal(t, 1, page.navCount)
+
+	newCompo, _ := r.createComponent("/users/21/posts")
+	d.Mount(newCompo)
+	d.Nav(&url.URL{Path: "/users/21/posts"})
+	d.Consume()
+
+	require.Equal(t, nested, layout.getOutlet())
+	require.Equal(t, page, nested.getOutlet())
+	require.Equal(t, "21", nested.ID)
+	require.True(t, page.Mounted())
+	require.Equal(t, 2, layout.navCount)
+	require.Equal(t, 2, nested.navCount)
+	require.Equal(t, 2, page.navCount)
+
+	newCompo, _ = r.createComponent("/")
+	d.Mount(newCompo)
+	d.Consume()
+
+	require.True(t, layout.Mounted())
+	require.False(t, nested.Mounted())
+	require.IsType(t, &routeCompo{}, layout.getOutlet())
+	require.True(t, layout.getOutlet().Mounted())
+	require.Equal(t, layout.getOutlet(), layout.root.getChildren()[0])
+}
+
+func TestRouteMiddlewares(t *testing.T) {
+	d := NewClientTester(Div())
+	defer d.Close()
+
+	r := NewRouter()
+	r.RouteLayout("/", &layoutCompo{})
+	r.Route("/", &routeCompo{})
+	r.Route("/admin", &layoutPageCompo{})
+	r.Use(
+		func(ctx Context, r *RouteRequest) {
+			if r.URL.Path == "/admin" && r.Cookie("session") == "" {
+				r.Redirect("/login")
+			}
+		},
+		func(ctx Context, r *RouteRequest) {
+			if r.Component() == nil {
+				r.Replace(&routeWithRegexpCompo{})
+			}
+		},
+	)
+
+	t.Run("navigation is allowed", func(t *testing.T) {
+		req := RouteRequest{URL: &url.URL{Path: "/"}}
+		compo := r.handle(d.Context(), &req)
+		require.IsType(t, &layoutCompo{}, compo)
+		require.IsType(t, &routeCompo{}, compo.getOutlet())
+		require.False(t, req.isReplaced)
+		require.Empty(t, req.redirectURL)
+	})
+
+	t.Run("navigation is redirected", func(t *testing.T) {
+		req := RouteRequest{URL: &url.URL{Path: "/admin"}}
+		compo := r.handle(d.Context(), &req)
+		require.Nil(t, compo)
+		require.Equal(t, "/login", req.redirectURL)
+	})
+
+	t.Run("navigation with cookie is allowed", func(t *testing.T) {
+		httpReq := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		httpReq.AddCookie(&http.Cookie{Name: "session", Value: "42"})
+
+		req := RouteRequest{
+			URL:         httpReq.URL,
+			httpRequest: httpReq,
+		}
+		compo := r.handle(d.Context(), &req)
+		require.Equal(t, "42", req.Cookie("session"))
+		require.IsType(t, &layoutPageCompo{}, compo.getOutlet())
+	})
+
+	t.Run("escaped cookie is decoded the same way on server and client", func(t *testing.T) {
+		httpReq := httptest.NewRequest(http.MethodGet, "/admin", nil)
+		httpReq.Header.Set("Cookie", `session=a%20b+c%2Fd; theme="dark%21"; raw=100%`)
+
+		req := RouteRequest{
+			URL:         httpReq.URL,
+			httpRequest: httpReq,
+		}
+		cookies := httpReq.Header.Get("Cookie")
+
+		for name, expected := range map[string]string{
+			"session": "a b+c/d",
+			"theme":   "dark!",
+			"raw":     "100%",
+			"missing": "",
+		} {
+			require.Equal(t, expected, req.Cookie(name))
+			require.Equal(t, expected, cookieValue(cookies, name))
+		}
+	})
+
+	t.Run("component is replaced", func(t *testing.T) {
+		req := RouteRequest{URL: &url.URL{Path: "/unknown"}}
+		compo := r.handle(d.Context(), &req)
+		require.True(t, req.isReplaced)
+		require.IsType(t, &layoutCompo{}, compo)
+		require.IsType(t, &routeWithRegexpCompo{}, compo.getOutlet())
+	})
+}
@@ -2,7 +2,10 @@ package app
 
 import (
 	"encoding/json"
+	"path"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +34,47 @@ type Observer interface {
 	Value(recv any)
 }
 
+// PatternObserver is an observer that observes changes for the states whose
+// name matches a pattern.
+//
+// A pattern is either a glob as defined by path.Match, where "*" matches any
+// sequence of characters except "/", or a prefix followed by "**" that matches
+// all the states starting with the prefix:
+//
+//	"/cart/items/*"  // Matches "/cart/items/42" but not "/cart/items/42/qty".
+//	"/cart/**"       // Matches "/cart/items/42" and "/cart/items/42/qty".
+type PatternObserver interface {
+	// Defines a condition that reports whether the observer keeps observing the
+	// matching states. Multiple conditions can be defined by successively
+	// calling While().
+	While(condition func() bool) PatternObserver
+
+	// Executes the given function on the UI goroutine each time a matching
+	// state is set or deleted.
+	OnChange(fn func(StateChange))
+}
+
+// StateChange describes a change of a state observed with a PatternObserver.
+type StateChange struct {
+	// The name of the state that changed.
+	State string
+
+	// Reports whether the state was deleted.
+	IsDeleted bool
+
+	value func(recv any) error
+}
+
+// Value stores the state value into the given receiver. It returns an error
+// when the receiver is not a pointer or when its pointed value has a different
+// type than the state value.
+func (c StateChange) Value(recv any) error {
+	if c.value == nil {
+		return storeValue(recv, nil)
+	}
+	return c.value(recv)
+}
+
 // A state represents an observable value available across the app.
 type State struct {
 	// Reports whether the state is persisted in local storage.
@@ -46,7 +90,16 @@ type State struct {
 	// Reports whether a state is broadcasted to other browser tabs and windows.
 	IsBroadcasted bool
 
+	// Reports whether a state set during pre-rendering is transferred to the
+	// client.
+	IsTransferred bool
+
+	// Reports whether a state is synchronized with the server.
+	IsSynced bool
+
 	value     any
+	updatedAt time.Time
+	updatedBy string
 	observers map[*observer]struct{}
 }
 
@@ -57,7 +110,8 @@ func (s *State) isExpired(now time.Time) bool {
 // StateOption represents an option applied when a state is set.
 type StateOption func(*State)
 
-// Persist is a state option that persists a state in local storage.
+// Persist is a state option that persists a state in the state storage, which
+// is local storage unless another storage is set with UseStateStorage.
 //
 // Be mindful to not use this option as a cache since local storage is limited
 // to 5MB in a lot of web browsers.
@@ -93,10 +147,194 @@ func ExpiresAt(t time.Time) StateOption {
 
 // Broadcast is a state option that broadcasts a state to other browser tabs and
 // windows from the same origin.
+//
+// Broadcasted states are replicated into the stores of the other tabs and
+// windows, including the ones opened later. When a state is concurrently
+// broadcasted from multiple tabs, the most recent write wins.
 func Broadcast(s *State) {
 	s.IsBroadcasted = true
 }
 
+// Sync is a state option that synchronizes a state with the server. It requires
+// the Handler.SyncStates option to be enabled.
+//
+// Synced states are sent to the server, which keeps them per client, and are
+// updated when the server sets them with Handler.SetState or
+// Handler.SetClientState. Changes made while the app is offline are sent when
+// the connection is restored, and the states are resynchronized with the
+// server. When a state is concurrently set on the client and on the server, the
+// last write received by the server wins.
+//
+// The state value must be serializable into JSON.
+func Sync(s *State) {
+	s.IsSynced = true
+}
+
+// StateKey is a typed handle to a state. It ensures that a state is always set,
+// read and observed with values of the same type.
+//
+// It is typically declared once at the package level:
+//
+//	var greetName = app.NewStateKey[string]("greet-name")
+//
+//	func (h *hello) OnMount(ctx app.Context) {
+//	    greetName.Observe(ctx).Value(&h.name)
+//	}
+type StateKey[T any] struct {
+	name string
+}
+
+// NewStateKey returns a typed handle to the state with the given name.
+func NewStateKey[T any](name string) StateKey[T] {
+	return StateKey[T]{name: name}
+}
+
+// Name returns the name of the state.
+func (k StateKey[T]) Name() string {
+	return k.name
+}
+
+// Set sets the state with the given value. It accepts the same options as
+// Context.SetState.
+func (k StateKey[T]) Set(ctx Context, v T, opts ...StateOption) {
+	ctx.SetState(k.name, v, opts...)
+}
+
+// Get returns the state value. The zero value is returned when the state is not
+// set.
+func (k StateKey[T]) Get(ctx Context) T {
+	var v T
+	ctx.GetState(k.name, &v)
+	return v
+}
+
+// Del deletes the state. All value observations are stopped.
+func (k StateKey[T]) Del(ctx Context) {
+	ctx.DelState(k.name)
+}
+
+// Observe creates an observer that observes changes for the state.
+func (k StateKey[T]) Observe(ctx Context) StateObserver[T] {
+	return StateObserver[T]{observer: ctx.ObserveState(k.name)}
+}
+
+// StateObserver is an observer that observes changes for a typed state.
+type StateObserver[T any] struct {
+	observer Observer
+}
+
+// While defines a condition that reports whether the observer keeps observing
+// the state. Multiple conditions can be defined by successively calling
+// While().
+func (o StateObserver[T]) While(condition func() bool) StateObserver[T] {
+	o.observer.While(condition)
+	return o
+}
+
+// OnChange executes the given function on the UI goroutine when the observed
+// value changes. Multiple functions can be executed by successively calling
+// OnChange().
+func (o StateObserver[T]) OnChange(fn func()) StateObserver[T] {
+	o.observer.OnChange(fn)
+	return o
+}
+
+// Value stores the state value into the given receiver. The receiver is
+// updated each time the state changes. Panics when the receiver is nil.
+func (o StateObserver[T]) Value(recv *T) {
+	if recv == nil {
+		panic(errors.New("observer value receiver is nil"))
+	}
+	o.observer.Value(recv)
+}
+
+// Transfer is a state option that transfers a state set on the server during
+// pre-rendering to the client. The state is serialized into the pre-rendered
+// page and is available in the client store before the first component is
+// mounted.
+//
+// The state value must be serializable into JSON.
+func Transfer(s *State) {
+	s.IsTransferred = true
+}
+
+// StateMigration is a function that upgrades the JSON encoded value of a
+// persisted state to the next version.
+type StateMigration func(v json.RawMessage) (json.RawMessage, error)
+
+// VersionState sets the version of the values persisted for the given state,
+// with the migrations that upgrade the values persisted with a previous
+// version. Migrations are indexed by the version they upgrade from. Values
+// persisted before a state is versioned have the version 0.
+//
+// Persisted values with a previous version are upgraded when read and persisted
+// again with the current version. Values that cannot be upgraded are deleted
+// and an error is logged.
+//
+// Example:
+//
+//	app.VersionState("/user", 2, map[int]app.StateMigration{
+//	    0: migrateUserV0ToV1,
+//	    1: migrateUserV1ToV2,
+//	})
+//
+// It must be called before the app is started.
+func VersionState(state string, version int, migrations map[int]StateMigration) {
+	stateVersionsMutex.Lock()
+	defer stateVersionsMutex.Unlock()
+
+	stateVersions[state] = stateVersion{
+		version:    version,
+		migrations: migrations,
+	}
+}
+
+var (
+	stateVersionsMutex sync.RWMutex
+	stateVersions      = make(map[string]stateVersion)
+)
+
+type stateVersion struct {
+	version    int
+	migrations map[int]StateMigration
+}
+
+// defaultStateVersions returns a copy of the versions set with VersionState.
+func defaultStateVersions() map[string]stateVersion {
+	stateVersionsMutex.RLock()
+	defer stateVersionsMutex.RUnlock()
+
+	versions := make(map[string]stateVersion, len(stateVersions))
+	for k, v := range stateVersions {
+		versions[k] = v
+	}
+	return versions
+}
+
+func (v stateVersion) migrate(from int, value json.RawMessage) (json.RawMessage, error) {
+	if from > v.version {
+		return nil, errors.New("persisted value version is newer than the state version").
+			WithTag("persisted-version", from).
+			WithTag("state-version", v.version)
+	}
+
+	for ; from < v.version; from++ {
+		migrate, ok := v.migrations[from]
+		if !ok {
+			return nil, errors.New("migration is missing").
+				WithTag("from-version", from)
+		}
+
+		var err error
+		if value, err = migrate(value); err != nil {
+			return nil, errors.New("migration failed").
+				WithTag("from-version", from).
+				Wrap(err)
+		}
+	}
+	return value, nil
+}
+
 type observer struct {
 	element    UI
 	subscribe  func(*observer)
@@ -132,11 +370,49 @@ func (o *observer) Value(recv any) {
 }
 
 func (o *observer) isObserving() bool {
-	if !o.element.Mounted() {
+	return isObserving(o.element, o.conditions)
+}
+
+type patternObserver struct {
+	pattern    string
+	element    UI
+	subscribe  func(*patternObserver)
+	conditions []func() bool
+	onChange   func(StateChange)
+}
+
+func newPatternObserver(pattern string, elem UI, subscribe func(*patternObserver)) *patternObserver {
+	return &patternObserver{
+		pattern:   pattern,
+		element:   elem,
+		subscribe: subscribe,
+	}
+}
+
+func (o *patternObserver) While(fn func() bool) PatternObserver {
+	o.conditions = append(o.conditions, fn)
+	return o
+}
+
+func (o *patternObserver) OnChange(fn func(StateChange)) {
+	if fn == nil {
+		panic(errors.New("pattern observer change function is nil"))
+	}
+
+	o.onChange = fn
+	o.subscribe(o)
+}
+
+func (o *patternObserver) isObserving() bool {
+	return isObserving(o.element, o.conditions)
+}
+
+func isObserving(elem UI, conditions []func() bool) bool {
+	if !elem.Mounted() {
 		return false
 	}
 
-	for _, c := range o.conditions {
+	for _, c := range conditions {
 		if !c() {
 			return false
 		}
@@ -145,20 +421,44 @@ func (o *observer) isObserving() bool {
 	return true
 }
 
+// matchStatePattern reports whether the given state name matches the given
+// pattern. See PatternObserver for the pattern syntax.
+func matchStatePattern(pattern, state string) bool {
+	if strings.HasSuffix(pattern, "**") {
+		return strings.HasPrefix(state, strings.TrimSuffix(pattern, "**"))
+	}
+
+	match, _ := path.Match(pattern, state)
+	return match
+}
+
 type store struct {
 	mutex            sync.Mutex
 	id               string
 	states           map[string]State
+	derived          map[string]*derivedState
+	patternObservers map[*patternObserver]struct{}
+	history          *stateHistory
+	batched          *stateNotifications
 	disp             Dispatcher
+	versions         map[string]stateVersion
 	broadcastChannel Value
 	onBroadcastClose func()
+	sync             *stateSyncClient
 }
 
 func newStore(d Dispatcher) *store {
 	s := &store{
-		id:     uuid.NewString(),
-		states: make(map[string]State),
-		disp:   d,
+		id:               uuid.NewString(),
+		states:           make(map[string]State),
+		derived:          make(map[string]*derivedState),
+		patternObservers: make(map[*patternObserver]struct{}),
+		disp:             d,
+		versions:         d.getStateVersions(),
+	}
+
+	if storage, ok := d.getStateStorage().(asyncLoader); ok {
+		storage.onLoad(s.onStorageLoad)
 	}
 
 	s.initBroadcast()
@@ -169,8 +469,27 @@ func (s *store) Set(key string, v any, opts ...StateOption) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	s.setAndRecord(key, v, opts...)
+}
+
+func (s *store) setAndRecord(key string, v any, opts ...StateOption) {
+	if _, ok := s.derived[key]; ok {
+		Log(errors.New("setting state failed").
+			WithTag("state", key).
+			WithTag("reason", "state is derived"))
+		return
+	}
+
+	previous := s.states[key]
+	s.set(key, v, opts...)
+	s.recordChange(key, previous, v)
+}
+
+func (s *store) set(key string, v any, opts ...StateOption) {
 	state := s.states[key]
 	state.value = v
+	state.updatedAt = time.Now()
+	state.updatedBy = s.id
 	for _, o := range opts {
 		o(&state)
 	}
@@ -191,65 +510,46 @@ func (s *store) Set(key string, v any, opts ...StateOption) {
 		return
 	}
 
+	if !s.propagate(key, state) {
+		return
+	}
+
+	s.notifyObservers(key, state, func(recv any) error {
+		return storeValue(recv, v)
+	})
+	s.invalidateDependents(key)
+}
+
+// propagate sends the given state to the other tabs or to the server when it
+// is broadcasted or synchronized. It reports whether the state was propagated.
+func (s *store) propagate(key string, state State) bool {
 	if state.IsBroadcasted {
-		if err := s.broadcast(key, v); err != nil {
+		if err := s.broadcast(key, state); err != nil {
 			Log(errors.New("broadcasting state failed").
 				WithTag("state", key).
 				Wrap(err))
-			return
+			return false
 		}
 	}
 
-	for obs := range state.observers {
-		o := obs
-
-		if !o.element.Mounted() {
-			delete(state.observers, o)
-			continue
+	if state.IsSynced {
+		if err := s.syncState(key, state); err != nil {
+			Log(errors.New("synchronizing state failed").
+				WithTag("state", key).
+				Wrap(err))
+			return false
 		}
-
-		s.disp.Dispatch(Dispatch{
-			Mode:   Update,
-			Source: o.element,
-			Function: func(ctx Context) {
-				if !o.isObserving() {
-					s.mutex.Lock()
-					delete(state.observers, o)
-					s.mutex.Unlock()
-					return
-				}
-
-				if err := storeValue(o.receiver, v); err != nil {
-					Log(errors.New("notifying observer failed").
-						WithTag("state", key).
-						WithTag("element", reflect.TypeOf(o.element)).
-						Wrap(err))
-					return
-				}
-
-				for _, fn := range o.onChanges {
-					fn()
-				}
-			},
-		})
 	}
+
+	return true
 }
 
 func (s *store) Get(key string, recv any) {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
 	var err error
-	state := s.states[key]
-	if state.isExpired(time.Now()) {
-		state = s.expire(key, state)
-		s.states[key] = state
-	}
-
-	if state.value != nil {
-		err = storeValue(recv, state.value)
+	if s.isDerived(key) {
+		err = s.getDerived(key, recv)
 	} else {
-		err = s.getPersistent(key, recv)
+		err = s.get(key, recv)
 	}
 	if err != nil {
 		Log(errors.New("getting state value failed").
@@ -262,16 +562,80 @@ func (s *store) Del(key string) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	delete(s.states, key)
-	s.disp.getLocalStorage().Del(key)
+	s.delAndRecord(key)
+}
+
+func (s *store) delAndRecord(key string) {
+	s.recordChange(key, s.states[key], nil)
+	s.del(key)
+}
+
+// List returns the sorted names of the states that match the given pattern,
+// whether they are in memory, derived or persisted. Persisted states are listed
+// only when the state storage can list its keys.
+func (s *store) List(pattern string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.list(pattern)
+}
+
+// DelMatching deletes the states that match the given pattern.
+func (s *store) DelMatching(pattern string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, k := range s.list(pattern) {
+		s.delAndRecord(k)
+	}
+}
+
+// ObservePattern creates an observer that observes changes for the states that
+// match the given pattern.
+func (s *store) ObservePattern(pattern string, elem UI) PatternObserver {
+	return newPatternObserver(pattern, elem, func(o *patternObserver) {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		s.patternObservers[o] = struct{}{}
+	})
+}
+
+// Derive declares a state whose value is computed by the given function from
+// the given source states. The value is computed lazily, when the derived state
+// is read or observed after one of its sources changed.
+func (s *store) Derive(key string, fn func(Context) any, sources ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, src := range sources {
+		if src == key || s.dependsOn(src, key) {
+			Log(errors.New("deriving state failed").
+				WithTag("state", key).
+				WithTag("source", src).
+				WithTag("reason", "circular dependency"))
+			return
+		}
+	}
+
+	s.derived[key] = &derivedState{
+		sources: sources,
+		compute: fn,
+	}
+	s.invalidate(key)
 }
 
 func (s *store) Observe(key string, elem UI) Observer {
 	return newObserver(elem, func(o *observer) {
 		s.mutex.Lock()
-		defer s.mutex.Unlock()
+		_, isDerived := s.derived[key]
+		err := s.subscribe(key, o)
+		s.mutex.Unlock()
 
-		if err := s.subscribe(key, o); err != nil {
+		if err == nil && isDerived {
+			err = s.getDerived(key, o.receiver)
+		}
+		if err != nil {
 			Log(errors.New("notifying observer failed").
 				WithTag("state", key).
 				WithTag("element", reflect.TypeOf(elem)).
@@ -294,6 +658,10 @@ func (s *store) Close() {
 		s.broadcastChannel = nil
 	}
 	s.onBroadcastClose()
+
+	if s.sync != nil {
+		s.sync.close()
+	}
 }
 
 func (s *store) subscribe(key string, o *observer) error {
@@ -308,12 +676,108 @@ func (s *store) subscribe(key string, o *observer) error {
 	}
 	s.states[key] = state
 
+	if _, ok := s.derived[key]; ok {
+		return nil
+	}
 	if state.value != nil {
 		return storeValue(o.receiver, state.value)
 	}
 	return s.getPersistent(key, o.receiver)
 }
 
+func (s *store) get(key string, recv any) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state := s.states[key]
+	if state.isExpired(time.Now()) {
+		state = s.expire(key, state)
+		s.states[key] = state
+	}
+
+	if state.value != nil {
+		return storeValue(recv, state.value)
+	}
+	return s.getPersistent(key, recv)
+}
+
+func (s *store) isDerived(key string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.derived[key]
+	return ok
+}
+
+// getDerived stores the value of the given derived state into the given
+// receiver. The value is recomputed when the derived state is outdated.
+//
+// The computing function is called without holding the store lock since it
+// reads its sources from the store.
+func (s *store) getDerived(key string, recv any) error {
+	s.mutex.Lock()
+	d, ok := s.derived[key]
+	if !ok {
+		s.mutex.Unlock()
+		return nil
+	}
+	if !d.isOutdated {
+		v := d.value
+		s.mutex.Unlock()
+		return storeValue(recv, v)
+	}
+	d.isOutdated = false
+	s.mutex.Unlock()
+
+	v := d.compute(s.disp.Context())
+
+	s.mutex.Lock()
+	if !d.isOutdated {
+		d.value = v
+	}
+	s.mutex.Unlock()
+	return storeValue(recv, v)
+}
+
+// invalidate marks the given derived state as outdated and notifies its
+// observers and the derived states that depend on it.
+func (s *store) invalidate(key string) {
+	d, ok := s.derived[key]
+	if !ok || d.isOutdated {
+		return
+	}
+	d.isOutdated = true
+
+	s.notifyObservers(key, s.states[key], func(recv any) error {
+		return s.getDerived(key, recv)
+	})
+	s.invalidateDependents(key)
+}
+
+func (s *store) invalidateDependents(key string) {
+	for k, d := range s.derived {
+		if d.hasSource(key) {
+			s.invalidate(k)
+		}
+	}
+}
+
+// dependsOn reports whether the given state is derived, directly or not, from
+// the given source.
+func (s *store) dependsOn(key, source string) bool {
+	d, ok := s.derived[key]
+	if !ok {
+		return false
+	}
+
+	for _, src := range d.sources {
+		if src == source || s.dependsOn(src, source) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *store) removeUnusedObservers() {
 	for _, state := range s.states {
 		for o := range state.observers {
@@ -322,31 +786,148 @@ func (s *store) removeUnusedObservers() {
 			}
 		}
 	}
+
+	for o := range s.patternObservers {
+		if !o.isObserving() {
+			delete(s.patternObservers, o)
+		}
+	}
+}
+
+func (s *store) del(key string) {
+	state, isSet := s.states[key]
+	isSet = isSet && state.value != nil
+	_, isDerived := s.derived[key]
+
+	delete(s.states, key)
+	delete(s.derived, key)
+	s.disp.getStateStorage().Del(key)
+	s.invalidateDependents(key)
+
+	if isSet || isDerived {
+		s.notifyPatternObservers(key, true, nil)
+	}
+}
+
+func (s *store) list(pattern string) []string {
+	now := time.Now()
+	keys := make(map[string]struct{})
+
+	for k, state := range s.states {
+		if state.value != nil && !state.isExpired(now) && matchStatePattern(pattern, k) {
+			keys[k] = struct{}{}
+		}
+	}
+
+	for k := range s.derived {
+		if matchStatePattern(pattern, k) {
+			keys[k] = struct{}{}
+		}
+	}
+
+	if storage, ok := s.disp.getStateStorage().(keyLister); ok {
+		for _, k := range storage.keys() {
+			if _, ok := keys[k]; ok || !matchStatePattern(pattern, k) {
+				continue
+			}
+
+			// Storages can contain other items than persisted states. They
+			// are ignored by checking that they decode into a persisted state.
+			var state persistentState
+			if err := s.disp.getStateStorage().Get(k, &state); err != nil {
+				continue
+			}
+			if state.isEmpty() || state.isExpired(now) {
+				continue
+			}
+			keys[k] = struct{}{}
+		}
+	}
+
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
 }
 
 func (s *store) getPersistent(key string, recv any) error {
 	var state persistentState
-	s.disp.getLocalStorage().Get(key, &state)
+	s.disp.getStateStorage().Get(key, &state)
 
-	if state.EncryptedValue == nil && state.Value == nil && state.ExpiresAt == (time.Time{}) {
+	if state.isEmpty() {
 		return nil
 	}
 
 	if state.isExpired(time.Now()) {
-		s.disp.getLocalStorage().Del(key)
+		s.disp.getStateStorage().Del(key)
 		return nil
 	}
 
-	if len(state.EncryptedValue) == 0 {
-		return json.Unmarshal(state.Value, recv)
+	version := s.versions[key]
+	if state.Version == version.version {
+		if len(state.EncryptedValue) == 0 {
+			return json.Unmarshal(state.Value, recv)
+		}
+		return s.disp.Context().Decrypt(state.EncryptedValue, recv)
+	}
+
+	value := state.Value
+	isEncrypted := len(state.EncryptedValue) != 0
+	if isEncrypted {
+		if err := s.disp.Context().Decrypt(state.EncryptedValue, &value); err != nil {
+			return err
+		}
+	}
+
+	value, err := version.migrate(state.Version, value)
+	if err != nil {
+		s.disp.getStateStorage().Del(key)
+		return errors.New("migrating persisted state failed").
+			WithTag("reason", "persisted state is deleted").
+			Wrap(err)
+	}
+
+	if err := s.setPersistent(key, isEncrypted, state.ExpiresAt, value); err != nil {
+		return errors.New("persisting migrated state failed").Wrap(err)
+	}
+	return json.Unmarshal(value, recv)
+}
+
+// onStorageLoad notifies the observers of the persisted states that were loaded
+// asynchronously by the state storage.
+func (s *store) onStorageLoad(keys []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, k := range keys {
+		key := k
+		state := s.states[key]
+		if _, ok := s.derived[key]; ok || state.value != nil {
+			continue
+		}
+
+		var persisted persistentState
+		if err := s.disp.getStateStorage().Get(key, &persisted); err != nil || persisted.isEmpty() {
+			continue
+		}
+
+		s.notifyObservers(key, state, func(recv any) error {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+
+			return s.getPersistent(key, recv)
+		})
+		s.invalidateDependents(key)
 	}
-	return s.disp.Context().Decrypt(state.EncryptedValue, recv)
 }
 
 func (s *store) setPersistent(key string, encrypt bool, expiresAt time.Time, v any) error {
 	var err error
 
 	state := persistentState{
+		Version:   s.versions[key].version,
 		ExpiresAt: expiresAt,
 	}
 	if encrypt {
@@ -358,7 +939,7 @@ func (s *store) setPersistent(key string, encrypt bool, expiresAt time.Time, v a
 		return err
 	}
 
-	return s.disp.getLocalStorage().Set(key, state)
+	return s.disp.getStateStorage().Set(key, state)
 }
 
 func (s *store) expireExpiredValues() {
@@ -372,11 +953,58 @@ func (s *store) expireExpiredValues() {
 }
 
 func (s *store) expire(key string, state State) State {
-	s.disp.getLocalStorage().Del(key)
+	s.disp.getStateStorage().Del(key)
 	state.value = nil
+	s.invalidateDependents(key)
 	return state
 }
 
+// transferredStates returns the JSON encoded values of the states set with the
+// Transfer option.
+func (s *store) transferredStates() map[string]json.RawMessage {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	states := make(map[string]json.RawMessage)
+	for k, state := range s.states {
+		if !state.IsTransferred || state.value == nil {
+			continue
+		}
+
+		b, err := json.Marshal(state.value)
+		if err != nil {
+			Log(errors.New("transferring state failed").
+				WithTag("state", k).
+				Wrap(err))
+			continue
+		}
+		states[k] = b
+	}
+	return states
+}
+
+// loadTransferredStates seeds the store with the states transferred from the
+// server. States that already have a value are not overwritten.
+func (s *store) loadTransferredStates(data string) error {
+	var states map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &states); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for k, v := range states {
+		state := s.states[k]
+		if state.value != nil {
+			continue
+		}
+		state.value = jsonValue(v)
+		s.states[k] = state
+	}
+	return nil
+}
+
 func (s *store) initBroadcast() {
 	broadcastChannel := Window().Get("BroadcastChannel")
 	if !broadcastChannel.Truthy() {
@@ -387,41 +1015,156 @@ func (s *store) initBroadcast() {
 	s.broadcastChannel = broadcastChannel
 
 	onBroadcast := FuncOf(func(this Value, args []Value) any {
-		s.onBroadcast(args[0].Get("data"))
+		s.onBroadcast(args[0].Get("data").String())
 		return nil
 	})
 	s.onBroadcastClose = onBroadcast.Release
 
 	broadcastChannel.Set("onmessage", onBroadcast)
+	s.requestBroadcastSnapshot()
 }
 
-func (s *store) broadcast(key string, v any) error {
-	b, err := json.Marshal(v)
+func (s *store) broadcast(key string, state State) error {
+	rs, err := newRemoteState(key, state)
 	if err != nil {
 		return err
 	}
 
-	if s.broadcastChannel != nil {
-		s.broadcastChannel.Call("postMessage", map[string]any{
-			"StoreID": s.id,
-			"State":   key,
-			"Value":   string(b),
-		})
+	return s.postBroadcast(broadcastMessage{
+		Type:    broadcastStateUpdate,
+		StoreID: s.id,
+		States:  []remoteState{rs},
+	})
+}
+
+// requestBroadcastSnapshot asks the stores from the other browser tabs and
+// windows for the current value of their broadcasted states.
+func (s *store) requestBroadcastSnapshot() {
+	if err := s.postBroadcast(broadcastMessage{
+		Type:    broadcastSnapshotRequest,
+		StoreID: s.id,
+	}); err != nil {
+		Log(errors.New("requesting broadcasted states snapshot failed").Wrap(err))
+	}
+}
+
+func (s *store) broadcastSnapshot(to string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	var states []remoteState
+	for k, state := range s.states {
+		if !state.IsBroadcasted || state.value == nil || state.isExpired(now) {
+			continue
+		}
+
+		rs, err := newRemoteState(k, state)
+		if err != nil {
+			Log(errors.New("encoding broadcasted state failed").
+				WithTag("state", k).
+				Wrap(err))
+			continue
+		}
+		states = append(states, rs)
+	}
+	if len(states) == 0 {
+		return nil
 	}
+
+	return s.postBroadcast(broadcastMessage{
+		Type:    broadcastSnapshot,
+		StoreID: s.id,
+		To:      to,
+		States:  states,
+	})
+}
+
+func (s *store) postBroadcast(m broadcastMessage) error {
+	if s.broadcastChannel == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	s.broadcastChannel.Call("postMessage", string(b))
 	return nil
 }
 
-func (s *store) onBroadcast(event Value) {
-	if storeID := event.Get("StoreID").String(); storeID == "" || storeID == s.id {
+func (s *store) onBroadcast(data string) {
+	var m broadcastMessage
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		Log(errors.New("decoding broadcast message failed").Wrap(err))
 		return
 	}
+	if m.StoreID == "" || m.StoreID == s.id {
+		return
+	}
+
+	switch m.Type {
+	case broadcastSnapshotRequest:
+		if err := s.broadcastSnapshot(m.StoreID); err != nil {
+			Log(errors.New("broadcasting states snapshot failed").Wrap(err))
+		}
 
+	case broadcastSnapshot:
+		if m.To == s.id {
+			s.receiveRemoteStates(m.States, Broadcast)
+		}
+
+	default:
+		s.receiveRemoteStates(m.States, Broadcast)
+	}
+}
+
+// receiveRemoteStates updates the store with the states received from another
+// browser tab or window. The given options are applied to the updated states.
+// Concurrent writes are resolved by keeping the most recent one.
+func (s *store) receiveRemoteStates(states []remoteState, opts ...StateOption) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	key := event.Get("State").String()
-	v := []byte(event.Get("Value").String())
-	state := s.states[key]
+	for _, rs := range states {
+		if _, ok := s.derived[rs.State]; ok {
+			continue
+		}
+
+		state := s.states[rs.State]
+		if !rs.isNewerThan(state.updatedAt, state.updatedBy) {
+			continue
+		}
+		s.applyRemoteState(rs, rs.Time, opts...)
+	}
+}
+
+// applyRemoteState sets the value of the given remote state, updated at the
+// given time, and notifies its observers.
+func (s *store) applyRemoteState(rs remoteState, updatedAt time.Time, opts ...StateOption) {
+	state := s.states[rs.State]
+	state.value = rs.value()
+	for _, o := range opts {
+		o(&state)
+	}
+	state.updatedAt = updatedAt
+	state.updatedBy = rs.StoreID
+	s.states[rs.State] = state
+
+	v := state.value
+	s.notifyObservers(rs.State, state, func(recv any) error {
+		return storeValue(recv, v)
+	})
+	s.invalidateDependents(rs.State)
+}
+
+// notifyObservers dispatches an update to each observer of the given state.
+// The given function stores the new state value into an observer receiver.
+func (s *store) notifyObservers(key string, state State, setValue func(recv any) error) {
+	if s.batched != nil {
+		s.batched.add(key, false, setValue)
+		return
+	}
 
 	for obs := range state.observers {
 		o := obs
@@ -442,7 +1185,7 @@ func (s *store) onBroadcast(event Value) {
 					return
 				}
 
-				if err := json.Unmarshal(v, o.receiver); err != nil {
+				if err := setValue(o.receiver); err != nil {
 					Log(errors.New("notifying observer failed").
 						WithTag("state", key).
 						WithTag("element", reflect.TypeOf(o.element)).
@@ -456,9 +1199,56 @@ func (s *store) onBroadcast(event Value) {
 			},
 		})
 	}
+
+	s.notifyPatternObservers(key, false, setValue)
+}
+
+// notifyPatternObservers dispatches the change of the given state to the
+// pattern observers that match it.
+func (s *store) notifyPatternObservers(key string, isDeleted bool, setValue func(recv any) error) {
+	if s.batched != nil {
+		s.batched.add(key, isDeleted, setValue)
+		return
+	}
+
+	for obs := range s.patternObservers {
+		o := obs
+
+		if !matchStatePattern(o.pattern, key) {
+			continue
+		}
+
+		if !o.element.Mounted() {
+			delete(s.patternObservers, o)
+			continue
+		}
+
+		s.disp.Dispatch(Dispatch{
+			Mode:   Update,
+			Source: o.element,
+			Function: func(ctx Context) {
+				if !o.isObserving() {
+					s.mutex.Lock()
+					delete(s.patternObservers, o)
+					s.mutex.Unlock()
+					return
+				}
+
+				o.onChange(StateChange{
+					State:     key,
+					IsDeleted: isDeleted,
+					value:     setValue,
+				})
+			},
+		})
+	}
 }
 
 func storeValue(recv, v any) error {
+	if b, ok := v.(jsonValue); ok {
+		return json.Unmarshal(b, recv)
+	}
+
 	dst := reflect.ValueOf(recv)
 	if dst.Kind() != reflect.Ptr {
 		return errors.New("receiver is not a pointer")
@@ -471,7 +1261,7 @@ func storeValue(recv, v any) error {
 		dst.Set(reflect.Zero(dst.Type()))
 		return nil
 
-	case src.Kind() == reflect.Ptr:
+	case src.Kind() == reflect.Ptr && src.Type() != dst.Type():
 		src = src.Elem()
 	}
 
@@ -485,17 +1275,103 @@ func storeValue(recv, v any) error {
 	return nil
 }
 
+// jsonValue is a state value that is still JSON encoded, such as a value
+// transferred from the server. It is decoded into the receiver type when read.
+type jsonValue []byte
+
+func (v jsonValue) MarshalJSON() ([]byte, error) {
+	if len(v) == 0 {
+		return []byte("null"), nil
+	}
+	return v, nil
+}
+
+type derivedState struct {
+	sources    []string
+	compute    func(Context) any
+	value      any
+	isOutdated bool
+}
+
+func (d *derivedState) hasSource(key string) bool {
+	for _, src := range d.sources {
+		if src == key {
+			return true
+		}
+	}
+	return false
+}
+
 type persistentState struct {
+	Version        int             `json:",omitempty"`
 	Value          json.RawMessage `json:",omitempty"`
 	EncryptedValue []byte          `json:",omitempty"`
 	ExpiresAt      time.Time       `json:",omitempty"`
 }
 
+func (s *persistentState) isEmpty() bool {
+	return s.EncryptedValue == nil && s.Value == nil && s.ExpiresAt == (time.Time{})
+}
+
 func (s *persistentState) isExpired(now time.Time) bool {
 	return s.ExpiresAt != time.Time{} && now.After(s.ExpiresAt)
 }
 
-type broadcastState struct {
-	StoreID string          `json:""`
+const (
+	broadcastStateUpdate     = "state"
+	broadcastSnapshotRequest = "snapshot-request"
+	broadcastSnapshot        = "snapshot"
+)
+
+type broadcastMessage struct {
+	Type    string
+	StoreID string
+	To      string        `json:",omitempty"`
+	States  []remoteState `json:",omitempty"`
+}
+
+// remoteState is a state value exchanged with the stores from other browser
+// tabs and windows, or with the server.
+type remoteState struct {
+	State   string
 	Value   json.RawMessage `json:",omitempty"`
+	Time    time.Time
+	StoreID string
+
+	// The sequence number assigned by the state sync server, which orders the
+	// synced states.
+	Seq uint64 `json:",omitempty"`
+}
+
+func newRemoteState(key string, state State) (remoteState, error) {
+	b, err := json.Marshal(state.value)
+	if err != nil {
+		return remoteState{}, err
+	}
+
+	return remoteState{
+		State:   key,
+		Value:   b,
+		Time:    state.updatedAt,
+		StoreID: state.updatedBy,
+	}, nil
+}
+
+// isNewerThan reports whether the remote state was written after the given
+// time. Writes that occurred at the same time are ordered by store ID to make
+// all the stores converge to the same value.
+func (s remoteState) isNewerThan(t time.Time, storeID string) bool {
+	if !s.Time.Equal(t) {
+		return s.Time.After(t)
+	}
+	return s.StoreID > storeID
+}
+
+// value returns the remote state value that is still encoded in JSON, or nil
+// when the state has no value.
+func (s remoteState) value() any {
+	if len(s.Value) == 0 || string(s.Value) == "null" {
+		return nil
+	}
+	return jsonValue(s.Value)
 }
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 	"time"
@@ -167,6 +168,157 @@ func TestStorePersist(t *testing.T) {
 	})
 }
 
+func TestStoreStateStorage(t *testing.T) {
+	storage := newMemoryStorage()
+	d := &engine{StateStorage: storage}
+	d.init()
+	defer d.Close()
+
+	s := newStore(d)
+	defer s.Close()
+	key := "/test/store/stateStorage"
+
+	var v int
+	s.Set(key, 42, Persist)
+	delete(s.states, key)
+	s.Get(key, &v)
+	require.Equal(t, 42, v)
+	require.Equal(t, 1, storage.Len())
+	require.Zero(t, d.getLocalStorage().Len())
+
+	s.Del(key)
+	require.Zero(t, storage.Len())
+}
+
+func TestVersionState(t *testing.T) {
+	key := "/test/versionState"
+	VersionState(key, 3, nil)
+	defer func() {
+		stateVersionsMutex.Lock()
+		delete(stateVersions, key)
+		stateVersionsMutex.Unlock()
+	}()
+
+	d := &engine{}
+	d.init()
+	defer d.Close()
+	require.Equal(t, 3, d.StateVersions[key].version)
+
+	VersionState(key, 4, nil)
+	require.Equal(t, 3, d.StateVersions[key].version)
+}
+
+func TestStoreVersion(t *testing.T) {
+	key := "/test/store/version"
+	versions := map[string]stateVersion{}
+	versions[key] = stateVersion{version: 2, migrations: map[int]StateMigration{
+		0: func(v json.RawMessage) (json.RawMessage, error) {
+			var n int
+			if err := json.Unmarshal(v, &n); err != nil {
+				return nil, err
+			}
+			return json.Marshal(n * 2)
+		},
+		1: func(v json.RawMessage) (json.RawMessage, error) {
+			var n int
+			if err := json.Unmarshal(v, &n); err != nil {
+				return nil, err
+			}
+			return json.Marshal(n + 1)
+		},
+	}}
+	versions[key+"/missing"] = stateVersion{version: 1}
+
+	d := &engine{StateVersions: versions}
+	d.init()
+	defer d.Close()
+
+	s := newStore(d)
+	defer s.Close()
+
+	persisted := func(key string) persistentState {
+		var state persistentState
+		d.getStateStorage().Get(key, &state)
+		return state
+	}
+
+	t.Run("value is persisted with current version", func(t *testing.T) {
+		var v int
+		s.Set(key, 42, Persist)
+		delete(s.states, key)
+
+		s.Get(key, &v)
+		require.Equal(t, 42, v)
+		require.Equal(t, 2, persisted(key).Version)
+	})
+
+	t.Run("unversioned value is migrated", func(t *testing.T) {
+		d.getStateStorage().Set(key, persistentState{
+			Value: json.RawMessage("20"),
+		})
+
+		var v int
+		s.Get(key, &v)
+		require.Equal(t, 41, v)
+
+		state := persisted(key)
+		require.Equal(t, 2, state.Version)
+		require.Equal(t, json.RawMessage("41"), state.Value)
+	})
+
+	t.Run("encrypted value is migrated", func(t *testing.T) {
+		b, err := d.Context().Encrypt(10)
+		require.NoError(t, err)
+		d.getStateStorage().Set(key, persistentState{
+			Version:        1,
+			EncryptedValue: b,
+		})
+
+		var v int
+		s.Get(key, &v)
+		require.Equal(t, 11, v)
+
+		state := persisted(key)
+		require.Equal(t, 2, state.Version)
+		require.Empty(t, state.Value)
+		require.NotEmpty(t, state.EncryptedValue)
+	})
+
+	t.Run("value with newer version is deleted", func(t *testing.T) {
+		d.getStateStorage().Set(key, persistentState{
+			Version: 3,
+			Value:   json.RawMessage("42"),
+		})
+
+		var v int
+		s.Get(key, &v)
+		require.Zero(t, v)
+		require.Zero(t, persisted(key).Version)
+	})
+
+	t.Run("value that fails to migrate is deleted", func(t *testing.T) {
+		d.getStateStorage().Set(key, persistentState{
+			Value: json.RawMessage(`"hello"`),
+		})
+
+		var v int
+		s.Get(key, &v)
+		require.Zero(t, v)
+		require.Empty(t, persisted(key).Value)
+	})
+
+	t.Run("value without migration is deleted", func(t *testing.T) {
+		d.getStateStorage().Set(key+"/missing", persistentState{
+			Value: json.RawMessage("42"),
+		})
+
+		var v int
+		s.Get(key+"/missing", &v)
+		require.Zero(t, v)
+		require.Empty(t, persisted(key+"/missing").Value)
+	})
+}
+
 func TestStoreEncrypt(t *testing.T) {
 	d := NewClientTester(Div())
 	defer d.Close()
@@ -318,6 +470,174 @@ func TestStoreBroadcast(t *testing.T) {
 	})
 }
 
+func TestStoreTransfer(t *testing.T) {
+	server := NewServerTester(Div())
+	defer server.Close()
+	ss := newStore(server)
+	defer ss.Close()
+
+	ss.Set("/test/transfer/string", "hello", Transfer)
+	ss.Set("/test/transfer/slice", []int{1, 2, 3}, Transfer)
+	ss.Set("/test/transfer/func", func() {}, Transfer)
+	ss.Set("/test/transfer/notTransferred", 42)
+
+	states := ss.transferredStates()
+	require.Len(t, states, 2)
+	b, err := json.Marshal(states)
+	require.NoError(t, err)
+
+	foo := &foo{}
+	client := NewClientTester(foo)
+	defer client.Close()
+	cs := newStore(client)
+	defer cs.Close()
+
+	cs.Set("/test/transfer/slice", []int{42})
+	err = cs.loadTransferredStates(string(b))
+	require.NoError(t, err)
+
+	var s string
+	cs.Get("/test/transfer/string", &s)
+	require.Equal(t, "hello", s)
+
+	cs.Observe("/test/transfer/string", foo).Value(&foo.Bar)
+	require.Equal(t, "hello", foo.Bar)
+
+	var ints []int
+	cs.Get("/test/transfer/slice", &ints)
+	require.Equal(t, []int{42}, ints)
+
+	var i int
+	cs.Get("/test/transfer/notTransferred", &i)
+	require.Zero(t, i)
+
+	err = cs.loadTransferredStates("{")
+	require.Error(t, err)
+}
+
+func TestStoreBroadcastReplication(t *testing.T) {
+	var hub testBroadcastHub
+	key := "/test/store/replication"
+
+	d1 := NewClientTester(&foo{})
+	defer d1.Close()
+	s1 := newStore(d1)
+	defer s1.Close()
+	hub.connect(s1)
+
+	bar := &bar{}
+	d2 := NewClientTester(bar)
+	defer d2.Close()
+	s2 := newStore(d2)
+	defer s2.Close()
+	hub.connect(s2)
+
+	t.Run("broadcasted state updates receiving store", func(t *testing.T) {
+		var observed int
+		s2.Observe(key, bar).Value(&observed)
+
+		s1.Set(key, 42, Broadcast)
+		d2.Consume()
+		require.Equal(t, 42, observed)
+
+		var v int
+		s2.Get(key, &v)
+		require.Equal(t, 42, v)
+	})
+
+	t.Run("new store receives snapshot", func(t *testing.T) {
+		d3 := NewClientTester(Div())
+		defer d3.Close()
+		s3 := newStore(d3)
+		defer s3.Close()
+		hub.connect(s3)
+
+		s1.Set(key+"/local", 21)
+		s3.requestBroadcastSnapshot()
+
+		var v int
+		s3.Get(key, &v)
+		require.Equal(t, 42, v)
+
+		v = 0
+		s3.Get(key+"/local", &v)
+		require.Zero(t, v)
+	})
+
+	t.Run("older write is ignored", func(t *testing.T) {
+		s2.receiveRemoteStates([]remoteState{
+			{
+				State:   key,
+				Value:   json.RawMessage("84"),
+				Time:    time.Now().Add(-time.Hour),
+				StoreID: s1.id,
+			},
+		})
+
+		var v int
+		s2.Get(key, &v)
+		require.Equal(t, 42, v)
+	})
+
+	t.Run("concurrent writes are ordered by store id", func(t *testing.T) {
+		now := time.Now()
+		a := remoteState{
+			State:   key,
+			Value:   json.RawMessage("1"),
+			Time:    now,
+			StoreID: "a",
+		}
+		b := remoteState{
+			State:   key,
+			Value:   json.RawMessage("2"),
+			Time:    now,
+			StoreID: "b",
+		}
+
+		var v int
+		s1.receiveRemoteStates([]remoteState{a, b})
+		s1.Get(key, &v)
+		require.Equal(t, 2, v)
+
+		s2.receiveRemoteStates([]remoteState{b, a})
+		s2.Get(key, &v)
+		require.Equal(t, 2, v)
+	})
+}
+
+// testBroadcastHub connects stores through in-memory broadcast channels.
+type testBroadcastHub struct {
+	stores []*store
+}
+
+func (h *testBroadcastHub) connect(s *store) {
+	s.broadcastChannel = testBroadcastChannel{
+		hub:   h,
+		store: s,
+	}
+	h.stores = append(h.stores, s)
+}
+
+type testBroadcastChannel struct {
+	value
+
+	hub   *testBroadcastHub
+	store *store
+}
+
+func (c testBroadcastChannel) Call(m string, args ...any) Value {
+	if m != "postMessage" {
+		return nil
+	}
+
+	for _, s := range c.hub.stores {
+		if s != c.store {
+			s.onBroadcast(args[0].(string))
+		}
+	}
+	return nil
+}
+
 func TestStoreObserve(t *testing.T) {
 	key := "/test/observe"
 
@@ -464,6 +784,305 @@ func TestStoreObserve(t *testing.T) {
 	})
 }
 
+func TestStoreDerive(t *testing.T) {
+	firstName := "/test/derive/firstName"
+	lastName := "/test/derive/lastName"
+	fullName := "/test/derive/fullName"
+	greeting := "/test/derive/greeting"
+
+	setup := func() (ClientDispatcher, *foo, *int) {
+		foo := &foo{}
+		d := NewClientTester(foo)
+
+		computes := 0
+		d.DeriveState(fullName, func(ctx Context) any {
+			computes++
+
+			var first, last string
+			ctx.GetState(firstName, &first)
+			ctx.GetState(lastName, &last)
+			return first + " " + last
+		}, firstName, lastName)
+		return d, foo, &computes
+	}
+
+	t.Run("derived value is computed lazily", func(t *testing.T) {
+		d, _, computes := setup()
+		defer d.Close()
+
+		d.SetState(firstName, "Maxence")
+		d.SetState(lastName, "Charriere")
+		require.Zero(t, *computes)
+
+		var v string
+		d.GetState(fullName, &v)
+		require.Equal(t, "Maxence Charriere", v)
+		require.Equal(t, 1, *computes)
+
+		d.GetState(fullName, &v)
+		require.Equal(t, 1, *computes)
+
+		d.SetState(firstName, "Max")
+		d.GetState(fullName, &v)
+		require.Equal(t, "Max Charriere", v)
+		require.Equal(t, 2, *computes)
+	})
+
+	t.Run("derived value is observed", func(t *testing.T) {
+		d, foo, _ := setup()
+		defer d.Close()
+
+		d.SetState(firstName, "Maxence")
+		d.ObserveState(fullName, foo).Value(&foo.Bar)
+		require.Equal(t, "Maxence ", foo.Bar)
+
+		d.SetState(lastName, "Charriere")
+		d.Consume()
+		require.Equal(t, "Maxence Charriere", foo.Bar)
+	})
+
+	t.Run("derived value is derived from derived state", func(t *testing.T) {
+		d, foo, _ := setup()
+		defer d.Close()
+
+		d.DeriveState(greeting, func(ctx Context) any {
+			var name string
+			ctx.GetState(fullName, &name)
+			return "hello " + name
+		}, fullName)
+		d.ObserveState(greeting, foo).Value(&foo.Bar)
+		require.Equal(t, "hello  ", foo.Bar)
+
+		d.SetState(firstName, "Maxence")
+		d.Consume()
+		require.Equal(t, "hello Maxence ", foo.Bar)
+	})
+
+	t.Run("derived state is not set", func(t *testing.T) {
+		d, _, _ := setup()
+		defer d.Close()
+
+		var v string
+		d.SetState(fullName, "Lewis Hamilton")
+		d.GetState(fullName, &v)
+		require.Equal(t, " ", v)
+	})
+
+	t.Run("circular derived state is not declared", func(t *testing.T) {
+		d, _, _ := setup()
+		defer d.Close()
+
+		d.DeriveState(firstName, func(ctx Context) any {
+			return "loop"
+		}, fullName)
+
+		var v string
+		d.SetState(firstName, "Maxence")
+		d.GetState(firstName, &v)
+		require.Equal(t, "Maxence", v)
+	})
+
+	t.Run("deleted derived state is a regular state", func(t *testing.T) {
+		d, _, _ := setup()
+		defer d.Close()
+
+		var v string
+		d.DelState(fullName)
+		d.SetState(fullName, "Lewis Hamilton")
+		d.GetState(fullName, &v)
+		require.Equal(t, "Lewis Hamilton", v)
+	})
+}
+
+func TestStateKey(t *testing.T) {
+	foo := &foo{}
+	d := NewClientTester(foo)
+	defer d.Close()
+	ctx := d.Context()
+
+	t.Run("value is set and got", func(t *testing.T) {
+		key := NewStateKey[int]("/test/stateKey/int")
+		require.Equal(t, "/test/stateKey/int", key.Name())
+		require.Zero(t, key.Get(ctx))
+
+		key.Set(ctx, 42)
+		require.Equal(t, 42, key.Get(ctx))
+
+		key.Del(ctx)
+		require.Zero(t, key.Get(ctx))
+	})
+
+	t.Run("pointer value is set and got", func(t *testing.T) {
+		key := NewStateKey[*copyTester]("/test/stateKey/pointer")
+		require.Nil(t, key.Get(ctx))
+
+		v := &copyTester{Exported: 42}
+		key.Set(ctx, v)
+		require.Equal(t, v, key.Get(ctx))
+	})
+
+	t.Run("value is set with options", func(t *testing.T) {
+		key := NewStateKey[string]("/test/stateKey/options")
+
+		key.Set(ctx, "hello", Persist, Encrypt, ExpiresIn(time.Minute))
+		require.Equal(t, "hello", key.Get(ctx))
+
+		key.Set(ctx, "bye", Persist, ExpiresIn(-time.Minute))
+		require.Empty(t, key.Get(ctx))
+	})
+
+	t.Run("value is observed", func(t *testing.T) {
+		key := NewStateKey[string]("/test/stateKey/observe")
+		isOnChangeCalled := false
+
+		key.Set(ctx, "hi")
+		key.Observe(makeContext(foo)).
+			While(func() bool { return true }).
+			OnChange(func() { isOnChangeCalled = true }).
+			Value(&foo.Bar)
+		require.Equal(t, "hi", foo.Bar)
+
+		key.Set(ctx, "hello")
+		d.Consume()
+		require.Equal(t, "hello", foo.Bar)
+		require.True(t, isOnChangeCalled)
+
+		require.Panics(t, func() {
+			key.Observe(ctx).Value(nil)
+		})
+	})
+}
+
+func TestMatchStatePattern(t *testing.T) {
+	utests := []struct {
+		pattern string
+		state   string
+		match   bool
+	}{
+		{pattern: "/cart", state: "/cart", match: true},
+		{pattern: "/cart", state: "/cart/items", match: false},
+		{pattern: "/cart/items/*", state: "/cart/items/42", match: true},
+		{pattern: "/cart/items/*", state: "/cart/items/42/qty", match: false},
+		{pattern: "/cart/*/42", state: "/cart/items/42", match: true},
+		{pattern: "/cart/**", state: "/cart/items/42/qty", match: true},
+		{pattern: "/cart/**", state: "/cart", match: false},
+		{pattern: "**", state: "/user", match: true},
+		{pattern: "/cart/[", state: "/cart/[", match: false},
+	}
+
+	for _, u := range utests {
+		t.Run(u.pattern+" "+u.state, func(t *testing.T) {
+			require.Equal(t, u.match, matchStatePattern(u.pattern, u.state))
+		})
+	}
+}
+
+func TestStoreObservePattern(t *testing.T) {
+	foo := &foo{}
+	d := NewClientTester(foo)
+	defer d.Close()
+
+	s := newStore(d)
+	defer s.Close()
+
+	var changes []StateChange
+	isObserving := true
+	s.ObservePattern("/test/pattern/items/*", foo).
+		While(func() bool { return isObserving }).
+		OnChange(func(c StateChange) {
+			changes = append(changes, c)
+		})
+
+	t.Run("matching state change is observed", func(t *testing.T) {
+		changes = nil
+		s.Set("/test/pattern/items/42", 42)
+		s.Set("/test/pattern/other", 21)
+		d.Consume()
+		require.Len(t, changes, 1)
+		require.Equal(t, "/test/pattern/items/42", changes[0].State)
+		require.False(t, changes[0].IsDeleted)
+
+		var v int
+		err := changes[0].Value(&v)
+		require.NoError(t, err)
+		require.Equal(t, 42, v)
+
+		var str string
+		err = changes[0].Value(&str)
+		require.Error(t, err)
+	})
+
+	t.Run("matching state deletion is observed", func(t *testing.T) {
+		changes = nil
+		s.Del("/test/pattern/items/42")
+		s.Del("/test/pattern/items/unset")
+		d.Consume()
+		require.Len(t, changes, 1)
+		require.Equal(t, "/test/pattern/items/42", changes[0].State)
+		require.True(t, changes[0].IsDeleted)
+
+		v := 42
+		err := changes[0].Value(&v)
+		require.NoError(t, err)
+		require.Zero(t, v)
+	})
+
+	t.Run("observer that stop observing is removed from store", func(t *testing.T) {
+		changes = nil
+		isObserving = false
+		s.Set("/test/pattern/items/21", 21)
+		d.Consume()
+		require.Empty(t, changes)
+		require.Empty(t, s.patternObservers)
+	})
+
+	t.Run("observer without change function panics", func(t *testing.T) {
+		require.Panics(t, func() {
+			s.ObservePattern("/test/pattern/**", foo).OnChange(nil)
+		})
+	})
+}
+
+func TestStoreListAndDelMatching(t *testing.T) {
+	d := NewClientTester(Div())
+	defer d.Close()
+
+	s := newStore(d)
+	defer s.Close()
+
+	s.Set("/test/list/cart/items/1", 1)
+	s.Set("/test/list/cart/items/2", 2, Persist)
+	s.Set("/test/list/cart/total", 3)
+	s.Set("/test/list/user", "max")
+	s.Derive("/test/list/cart/count", func(Context) any { return 2 })
+	d.getStateStorage().Set("/test/list/cart/items/3", persistentState{
+		Value: json.RawMessage("3"),
+	})
+	d.getStateStorage().Set("/test/list/cart/items/other", "not a state")
+
+	require.Equal(t, []string{
+		"/test/list/cart/items/1",
+		"/test/list/cart/items/2",
+		"/test/list/cart/items/3",
+	}, s.List("/test/list/cart/items/*"))
+
+	require.Equal(t, []string{
+		"/test/list/cart/count",
+		"/test/list/cart/items/1",
+		"/test/list/cart/items/2",
+		"/test/list/cart/items/3",
+		"/test/list/cart/total",
+	}, s.List("/test/list/cart/**"))
+
+	s.DelMatching("/test/list/cart/**")
+	require.Empty(t, s.List("/test/list/cart/**"))
+	require.Equal(t, []string{"/test/list/user"}, s.List("/test/list/**"))
+
+	var v int
+	s.Get("/test/list/cart/items/3", &v)
+	require.Zero(t, v)
+}
+
 func TestRemoveUnusedObservers(t *testing.T) {
 	source := &foo{}
 	d := NewClientTester(source)
@@ -522,6 +1141,12 @@ func TestStoreValue(t *testing.T) {
 			recv:     &c.unexported,
 			expected: 0,
 		},
+		{
+			scenario: "pointer to pointer receiver",
+			src:      &nb,
+			recv:     &c.pointer,
+			expected: &nb,
+		},
 		{
 			scenario: "nil to pointer receiver",
 			src:      nil,
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+// StateBatch is a set of state changes that are applied at once.
+type StateBatch interface {
+	// Sets the given state with the given value when the batch is applied.
+	// It accepts the same options as Context.SetState.
+	Set(state string, v any, opts ...StateOption)
+
+	// Stores the value of the given state into the given receiver. Changes
+	// made within the batch are taken into account.
+	Get(state string, recv any)
+
+	// Deletes the given state when the batch is applied.
+	Del(state string)
+}
+
+type stateBatch struct {
+	store   *store
+	changes []stateBatchChange
+}
+
+type stateBatchChange struct {
+	state     string
+	value     any
+	opts      []StateOption
+	isDeleted bool
+}
+
+func (b *stateBatch) Set(state string, v any, opts ...StateOption) {
+	b.changes = append(b.changes, stateBatchChange{
+		state: state,
+		value: v,
+		opts:  opts,
+	})
+}
+
+func (b *stateBatch) Get(state string, recv any) {
+	for i := len(b.changes) - 1; i >= 0; i-- {
+		c := b.changes[i]
+		if c.state != state {
+			continue
+		}
+
+		var v any
+		if !c.isDeleted {
+			v = c.value
+		}
+		if err := storeValue(recv, v); err != nil {
+			Log(errors.New("getting state value failed").
+				WithTag("state", state).
+				Wrap(err))
+		}
+		return
+	}
+
+	b.store.Get(state, recv)
+}
+
+func (b *stateBatch) Del(state string) {
+	b.changes = append(b.changes, stateBatchChange{
+		state:     state,
+		isDeleted: true,
+	})
+}
+
+// stateNotifications collects the changes of the states modified while a batch
+// is applied, in order to notify the observers once with the final values.
+type stateNotifications struct {
+	keys      []string
+	isDeleted map[string]bool
+	setValues map[string]func(recv any) error
+}
+
+func (n *stateNotifications) add(key string, isDeleted bool, setValue func(recv any) error) {
+	if _, ok := n.isDeleted[key]; !ok {
+		n.keys = append(n.keys, key)
+	}
+	n.isDeleted[key] = isDeleted
+	n.setValues[key] = setValue
+}
+
+// Batch calls the given function with a batch where state changes are
+// recorded. When the function returns, the changes are applied atomically and
+// the observers of each modified state are notified once with its final value.
+// No change is applied when the function returns an error, which is returned.
+func (s *store) Batch(fn func(StateBatch) error) error {
+	b := &stateBatch{store: s}
+	if err := fn(b); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.batched = &stateNotifications{
+		isDeleted: make(map[string]bool),
+		setValues: make(map[string]func(recv any) error),
+	}
+	for _, c := range b.changes {
+		if c.isDeleted {
+			s.delAndRecord(c.state)
+			continue
+		}
+		s.setAndRecord(c.state, c.value, c.opts...)
+	}
+	n := s.batched
+	s.batched = nil
+
+	for _, k := range n.keys {
+		if n.isDeleted[k] {
+			s.notifyPatternObservers(k, true, nil)
+			continue
+		}
+		s.notifyObservers(k, s.states[k], n.setValues[k])
+	}
+	return nil
+}
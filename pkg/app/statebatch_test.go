@@ -0,0 +1,153 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreBatch(t *testing.T) {
+	foo := &foo{}
+	d := NewClientTester(foo)
+	defer d.Close()
+
+	s := newStore(d)
+	defer s.Close()
+
+	var a, b, changes int
+	s.Observe("/test/batch/a", foo).
+		OnChange(func() { changes++ }).
+		Value(&a)
+	s.Observe("/test/batch/b", foo).
+		OnChange(func() { changes++ }).
+		Value(&b)
+
+	var patternChanges []StateChange
+	s.ObservePattern("/test/batch/**", foo).OnChange(func(c StateChange) {
+		patternChanges = append(patternChanges, c)
+	})
+
+	t.Run("observers are notified once with final values", func(t *testing.T) {
+		changes = 0
+		patternChanges = nil
+
+		err := s.Batch(func(sb StateBatch) error {
+			for i := 1; i <= 10; i++ {
+				sb.Set("/test/batch/a", i)
+			}
+			sb.Set("/test/batch/b", 42)
+			return nil
+		})
+		require.NoError(t, err)
+		d.Consume()
+
+		require.Equal(t, 10, a)
+		require.Equal(t, 42, b)
+		require.Equal(t, 2, changes)
+		require.Len(t, patternChanges, 2)
+		require.Equal(t, "/test/batch/a", patternChanges[0].State)
+		require.Equal(t, "/test/batch/b", patternChanges[1].State)
+	})
+
+	t.Run("batch changes are read before being applied", func(t *testing.T) {
+		err := s.Batch(func(sb StateBatch) error {
+			sb.Set("/test/batch/a", 21)
+
+			var v int
+			sb.Get("/test/batch/a", &v)
+			require.Equal(t, 21, v)
+
+			s.Get("/test/batch/a", &v)
+			require.Equal(t, 10, v)
+
+			sb.Del("/test/batch/a")
+			sb.Get("/test/batch/a", &v)
+			require.Zero(t, v)
+
+			sb.Get("/test/batch/b", &v)
+			require.Equal(t, 42, v)
+			return errors.New("test")
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("deleted state is notified to pattern observers", func(t *testing.T) {
+		patternChanges = nil
+
+		err := s.Batch(func(sb StateBatch) error {
+			sb.Set("/test/batch/c", 1)
+			sb.Del("/test/batch/c")
+			return nil
+		})
+		require.NoError(t, err)
+		d.Consume()
+
+		require.Len(t, patternChanges, 1)
+		require.Equal(t, "/test/batch/c", patternChanges[0].State)
+		require.True(t, patternChanges[0].IsDeleted)
+		require.Empty(t, s.List("/test/batch/c"))
+	})
+
+	t.Run("changes are rolled back when batch function returns an error", func(t *testing.T) {
+		changes = 0
+		patternChanges = nil
+
+		err := s.Batch(func(sb StateBatch) error {
+			sb.Set("/test/batch/b", 84)
+			sb.Set("/test/batch/d", 84)
+			return errors.New("test")
+		})
+		require.Error(t, err)
+		d.Consume()
+
+		require.Equal(t, 42, b)
+		require.Zero(t, changes)
+		require.Empty(t, patternChanges)
+		require.Empty(t, s.List("/test/batch/d"))
+	})
+
+	t.Run("dependent derived states are invalidated", func(t *testing.T) {
+		s.Derive("/test/batch/sum", func(ctx Context) any {
+			var a, b int
+			s.Get("/test/batch/a", &a)
+			s.Get("/test/batch/b", &b)
+			return a + b
+		}, "/test/batch/a", "/test/batch/b")
+
+		var sum, sumChanges int
+		s.Observe("/test/batch/sum", foo).
+			OnChange(func() { sumChanges++ }).
+			Value(&sum)
+		require.Equal(t, 52, sum)
+
+		err := s.Batch(func(sb StateBatch) error {
+			sb.Set("/test/batch/a", 1)
+			sb.Set("/test/batch/b", 2)
+			return nil
+		})
+		require.NoError(t, err)
+		d.Consume()
+
+		require.Equal(t, 3, sum)
+		require.Equal(t, 1, sumChanges)
+	})
+
+	t.Run("changes are recorded in history", func(t *testing.T) {
+		s.EnableHistory(10)
+		defer s.EnableHistory(0)
+
+		err := s.Batch(func(sb StateBatch) error {
+			sb.Set("/test/batch/b", 3)
+			sb.Set("/test/batch/b", 4)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Len(t, s.History(), 2)
+
+		require.True(t, s.Undo("/test/batch/b"))
+		var v int
+		s.Get("/test/batch/b", &v)
+		require.Equal(t, 3, v)
+	})
+}
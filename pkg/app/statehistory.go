@@ -0,0 +1,237 @@
+package app
+
+import (
+	"time"
+)
+
+// StateHistoryEntry describes a state change recorded in the state history.
+type StateHistoryEntry struct {
+	// The time when the change occurred.
+	Time time.Time
+
+	// The name of the state that changed.
+	State string
+
+	// Reports whether the state was deleted or set with a nil value.
+	IsDeleted bool
+
+	value           any
+	previous        any
+	options         State
+	previousOptions State
+}
+
+// Value stores the value the state had after the change into the given
+// receiver. The receiver is set to its zero value when the state was deleted.
+func (e StateHistoryEntry) Value(recv any) error {
+	return storeValue(recv, e.value)
+}
+
+// PreviousValue stores the value the state had before the change into the
+// given receiver. The receiver is set to its zero value when the state was not
+// set.
+func (e StateHistoryEntry) PreviousValue(recv any) error {
+	return storeValue(recv, e.previous)
+}
+
+// stateHistory records the state changes that can be undone and the undone
+// changes that can be redone. Both are bounded to the history size.
+type stateHistory struct {
+	size    int
+	exclude []string
+	changes []StateHistoryEntry
+	undone  []StateHistoryEntry
+}
+
+func (h *stateHistory) isRecorded(key string, state State) bool {
+	if state.IsEncrypted {
+		return false
+	}
+
+	for _, pattern := range h.exclude {
+		if matchStatePattern(pattern, key) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *stateHistory) record(e StateHistoryEntry) {
+	h.changes = h.bounded(append(h.changes, e))
+
+	// A new change makes the undone changes of the same state obsolete.
+	undone := h.undone[:0]
+	for _, u := range h.undone {
+		if u.State != e.State {
+			undone = append(undone, u)
+		}
+	}
+	h.undone = undone
+}
+
+func (h *stateHistory) bounded(entries []StateHistoryEntry) []StateHistoryEntry {
+	if len(entries) <= h.size {
+		return entries
+	}
+	return append(entries[:0], entries[len(entries)-h.size:]...)
+}
+
+// EnableHistory starts recording the state changes, keeping at most the given
+// number of changes. States that are encrypted or that match one of the given
+// patterns are not recorded. The history is disabled when size is zero or
+// less.
+func (s *store) EnableHistory(size int, exclude ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if size <= 0 {
+		s.history = nil
+		return
+	}
+
+	s.history = &stateHistory{
+		size:    size,
+		exclude: exclude,
+	}
+}
+
+// History returns the recorded changes that can be undone, from the oldest to
+// the most recent.
+func (s *store) History() []StateHistoryEntry {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.history == nil {
+		return nil
+	}
+	return append([]StateHistoryEntry(nil), s.history.changes...)
+}
+
+// Undo reverts the most recent change of a state that matches the given
+// pattern. It reports whether a change was undone.
+func (s *store) Undo(pattern string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.history == nil {
+		return false
+	}
+
+	e, ok := popStateHistoryEntry(&s.history.changes, pattern)
+	if !ok {
+		return false
+	}
+	s.history.undone = s.history.bounded(append(s.history.undone, e))
+	s.restore(e.State, e.previous, e.previousOptions)
+	return true
+}
+
+// Redo reapplies the most recent undone change of a state that matches the
+// given pattern. It reports whether a change was redone.
+func (s *store) Redo(pattern string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.history == nil {
+		return false
+	}
+
+	e, ok := popStateHistoryEntry(&s.history.undone, pattern)
+	if !ok {
+		return false
+	}
+	s.history.changes = s.history.bounded(append(s.history.changes, e))
+	s.restore(e.State, e.value, e.options)
+	return true
+}
+
+// recordChange records the change of the given state, from the given previous
+// state to the given value. It must be called before the state is deleted, or
+// after it is set.
+func (s *store) recordChange(key string, previous State, v any) {
+	if s.history == nil || (previous.value == nil && v == nil) {
+		return
+	}
+
+	state := s.states[key]
+	if !s.history.isRecorded(key, state) {
+		return
+	}
+
+	s.history.record(StateHistoryEntry{
+		Time:            time.Now(),
+		State:           key,
+		IsDeleted:       v == nil,
+		value:           v,
+		previous:        previous.value,
+		options:         stateOptions(state),
+		previousOptions: stateOptions(previous),
+	})
+}
+
+// restore sets the given state with the given value and options without
+// recording the change. The state value is cleared when the value is nil.
+func (s *store) restore(key string, v any, options State) {
+	if v == nil {
+		s.unset(key)
+		return
+	}
+	s.set(key, v, func(state *State) {
+		state.IsPersistent = options.IsPersistent
+		state.IsEncrypted = options.IsEncrypted
+		state.ExpiresAt = options.ExpiresAt
+		state.IsBroadcasted = options.IsBroadcasted
+		state.IsTransferred = options.IsTransferred
+		state.IsSynced = options.IsSynced
+	})
+}
+
+// stateOptions returns the options of the given state, without its value and
+// observers.
+func stateOptions(state State) State {
+	return State{
+		IsPersistent:  state.IsPersistent,
+		IsEncrypted:   state.IsEncrypted,
+		ExpiresAt:     state.ExpiresAt,
+		IsBroadcasted: state.IsBroadcasted,
+		IsTransferred: state.IsTransferred,
+		IsSynced:      state.IsSynced,
+	}
+}
+
+// unset clears the value of the given state and notifies its observers with a
+// zero value. Unlike del, the state observers and options are kept, so the
+// observers are notified when the state is set again.
+func (s *store) unset(key string) {
+	state, ok := s.states[key]
+	if !ok {
+		return
+	}
+	state.value = nil
+	state.updatedAt = time.Now()
+	state.updatedBy = s.id
+	s.states[key] = state
+
+	if state.IsPersistent {
+		s.disp.getStateStorage().Del(key)
+	}
+	if !s.propagate(key, state) {
+		return
+	}
+
+	s.notifyObservers(key, state, func(recv any) error {
+		return storeValue(recv, nil)
+	})
+	s.invalidateDependents(key)
+}
+
+func popStateHistoryEntry(entries *[]StateHistoryEntry, pattern string) (StateHistoryEntry, bool) {
+	for i := len(*entries) - 1; i >= 0; i-- {
+		e := (*entries)[i]
+		if matchStatePattern(pattern, e.State) {
+			*entries = append((*entries)[:i], (*entries)[i+1:]...)
+			return e, true
+		}
+	}
+	return StateHistoryEntry{}, false
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreHistory(t *testing.T) {
+	d := NewClientTester(Div())
+	defer d.Close()
+
+	s := newStore(d)
+	defer s.Close()
+
+	getInt := func(key string) int {
+		var v int
+		s.Get(key, &v)
+		return v
+	}
+
+	t.Run("changes are not recorded when history is disabled", func(t *testing.T) {
+		s.Set("/test/history/a", 1)
+		require.Empty(t, s.History())
+		require.False(t, s.Undo("**"))
+		require.False(t, s.Redo("**"))
+	})
+
+	s.EnableHistory(3, "/test/history/excluded/**")
+
+	t.Run("changes are recorded", func(t *testing.T) {
+		s.Set("/test/history/a", 2)
+		s.Set("/test/history/excluded/mouse", 42)
+		s.Set("/test/history/secret", 42, Persist, Encrypt)
+		s.Del("/test/history/a")
+
+		history := s.History()
+		require.Len(t, history, 2)
+		require.Equal(t, "/test/history/a", history[0].State)
+		require.False(t, history[0].IsDeleted)
+		require.True(t, history[1].IsDeleted)
+
+		var v int
+		require.NoError(t, history[0].Value(&v))
+		require.Equal(t, 2, v)
+		require.NoError(t, history[0].PreviousValue(&v))
+		require.Equal(t, 1, v)
+		require.NoError(t, history[1].Value(&v))
+		require.Zero(t, v)
+	})
+
+	t.Run("changes are undone and redone", func(t *testing.T) {
+		require.True(t, s.Undo("/test/history/a"))
+		require.Equal(t, 2, getInt("/test/history/a"))
+
+		require.True(t, s.Undo("/test/history/a"))
+		require.Equal(t, 1, getInt("/test/history/a"))
+		require.Empty(t, s.History())
+		require.False(t, s.Undo("/test/history/a"))
+
+		require.True(t, s.Redo("/test/history/a"))
+		require.Equal(t, 2, getInt("/test/history/a"))
+
+		require.True(t, s.Redo("/test/history/a"))
+		require.Zero(t, getInt("/test/history/a"))
+		require.False(t, s.Redo("/test/history/a"))
+		require.Len(t, s.History(), 2)
+	})
+
+	t.Run("changes of selected states are undone", func(t *testing.T) {
+		s.Set("/test/history/editor/title", 1)
+		s.Set("/test/history/b", 1)
+
+		require.True(t, s.Undo("/test/history/editor/**"))
+		require.Zero(t, getInt("/test/history/editor/title"))
+		require.Equal(t, 1, getInt("/test/history/b"))
+	})
+
+	t.Run("new change discards undone changes of the state", func(t *testing.T) {
+		s.Set("/test/history/editor/title", 2)
+		require.False(t, s.Redo("/test/history/editor/**"))
+		require.Equal(t, 2, getInt("/test/history/editor/title"))
+	})
+
+	t.Run("history is bounded", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			s.Set("/test/history/c", i)
+		}
+
+		history := s.History()
+		require.Len(t, history, 3)
+
+		var v int
+		require.NoError(t, history[0].Value(&v))
+		require.Equal(t, 7, v)
+	})
+
+	t.Run("observers are notified of undone changes", func(t *testing.T) {
+		foo := &foo{}
+		d := NewClientTester(foo)
+		defer d.Close()
+
+		s := newStore(d)
+		defer s.Close()
+		s.EnableHistory(10)
+
+		s.Observe("/test/history/observed", foo).Value(&foo.Bar)
+		s.Set("/test/history/observed", "hello")
+		s.Set("/test/history/observed", "world")
+		d.Consume()
+		require.Equal(t, "world", foo.Bar)
+
+		s.Undo("**")
+		d.Consume()
+		require.Equal(t, "hello", foo.Bar)
+	})
+
+	t.Run("observers are notified when first set is undone and redone", func(t *testing.T) {
+		foo := &foo{}
+		d := NewClientTester(foo)
+		defer d.Close()
+
+		s := newStore(d)
+		defer s.Close()
+		s.EnableHistory(10)
+
+		changes := 0
+		s.Observe("/test/history/first", foo).
+			OnChange(func() { changes++ }).
+			Value(&foo.Bar)
+
+		s.Set("/test/history/first", "hello", Persist)
+		d.Consume()
+		require.Equal(t, "hello", foo.Bar)
+		require.Equal(t, 1, changes)
+
+		require.True(t, s.Undo("**"))
+		d.Consume()
+		require.Empty(t, foo.Bar)
+		require.Equal(t, 2, changes)
+		require.Len(t, s.states["/test/history/first"].observers, 1)
+		require.True(t, s.states["/test/history/first"].IsPersistent)
+		require.Empty(t, s.List("/test/history/first"))
+
+		require.True(t, s.Redo("**"))
+		d.Consume()
+		require.Equal(t, "hello", foo.Bar)
+		require.Equal(t, 3, changes)
+
+		s.Set("/test/history/first", "world")
+		d.Consume()
+		require.Equal(t, "world", foo.Bar)
+		require.Equal(t, 4, changes)
+	})
+
+	t.Run("undone deletion restores state options", func(t *testing.T) {
+		key := "/test/history/persisted"
+		persisted := func() bool {
+			var state persistentState
+			d.getStateStorage().Get(key, &state)
+			return !state.isEmpty()
+		}
+
+		s.EnableHistory(10)
+		s.Set(key, 42, Persist)
+		s.Del(key)
+		require.False(t, persisted())
+
+		require.True(t, s.Undo(key))
+		require.Equal(t, 42, getInt(key))
+		require.True(t, s.states[key].IsPersistent)
+		require.True(t, persisted())
+
+		require.True(t, s.Redo(key))
+		require.Zero(t, getInt(key))
+		require.False(t, persisted())
+
+		require.True(t, s.Undo(key))
+		delete(s.states, key)
+		require.Equal(t, 42, getInt(key))
+	})
+
+	t.Run("history is disabled", func(t *testing.T) {
+		s.EnableHistory(0)
+		require.Nil(t, s.history)
+	})
+}
@@ -0,0 +1,644 @@
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/maxence-charriere/go-app/v9/pkg/errors"
+)
+
+const (
+	// The store ID of the states set from the server.
+	stateSyncServerID = "server"
+
+	// The response header that contains the sequence number assigned to the
+	// states sent by a client.
+	stateSyncSeqHeader = "Goapp-State-Seq"
+
+	// The number of state pushes that can be queued for a client connection
+	// before the connection is closed and the client resynchronizes.
+	stateSyncBufferSize = 64
+
+	// The maximum size of the states sent by a client at once.
+	stateSyncMaxBodySize = 1 << 20
+
+	// The duration after which the states of a client that is not connected
+	// are removed.
+	stateSyncClientTTL = time.Hour * 24
+
+	// The maximum number of clients whose states are kept. The states of the
+	// least recently seen client that is not connected are removed when a new
+	// client exceeds it.
+	stateSyncMaxClients = 10000
+
+	// The delay before reconnecting to the server when the connection has been
+	// closed.
+	stateSyncReconnectDelay = time.Second * 5
+
+	eventSourceClosed = 2
+)
+
+// stateSyncServer keeps the synced states of the clients and pushes their
+// changes with server-sent events.
+//
+// GET requests open an event stream that starts with a snapshot of the client
+// states. POST requests receive the states set by a client. Clients are
+// identified by the "client" query parameter, and requests are served only
+// when the authorize function accepts them. Connections are opened by a
+// store, identified by the "store" query parameter, which does not receive the
+// states it sent.
+//
+// States are ordered with a sequence number assigned by the server, so the
+// last state received wins regardless of the client clocks. The states of the
+// clients that are not connected are removed after stateSyncClientTTL, or
+// earlier when there are more than maxClients.
+type stateSyncServer struct {
+	mutex        sync.Mutex
+	authorize    func(*http.Request, string) bool
+	maxClients   int
+	seq          uint64
+	states       map[string]remoteState
+	clientStates map[string]map[string]remoteState
+	lastSeen     map[string]time.Time
+	conns        map[string]map[chan []remoteState]string
+}
+
+func newStateSyncServer(authorize func(*http.Request, string) bool) *stateSyncServer {
+	return &stateSyncServer{
+		authorize:    authorize,
+		maxClients:   stateSyncMaxClients,
+		states:       make(map[string]remoteState),
+		clientStates: make(map[string]map[string]remoteState),
+		lastSeen:     make(map[string]time.Time),
+		conns:        make(map[string]map[chan []remoteState]string),
+	}
+}
+
+// set sets the given state for the client with the given ID, or for all the
+// clients when the client ID is empty.
+func (s *stateSyncServer) set(clientID, state string, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.seq++
+	rs := remoteState{
+		State:   state,
+		Value:   b,
+		Time:    time.Now(),
+		StoreID: stateSyncServerID,
+		Seq:     s.seq,
+	}
+
+	if clientID == "" {
+		s.states[state] = rs
+		for id := range s.conns {
+			s.push(id, []remoteState{rs})
+		}
+		return nil
+	}
+
+	s.clientStatesOf(clientID)[state] = rs
+	s.push(clientID, []remoteState{rs})
+	return nil
+}
+
+func (s *stateSyncServer) get(clientID, state string, recv any) error {
+	s.mutex.Lock()
+	rs, _ := s.latest(clientID, state)
+	s.mutex.Unlock()
+
+	return storeValue(recv, rs.value())
+}
+
+// receive stores the states sent by a client and pushes them to the other
+// connections of the client. The states are given the returned sequence
+// number, which makes the last received state win regardless of the client
+// clock.
+func (s *stateSyncServer) receive(clientID string, states []remoteState) uint64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if len(states) == 0 {
+		return s.seq
+	}
+
+	s.seq++
+	now := time.Now()
+	clientStates := s.clientStatesOf(clientID)
+	for i := range states {
+		states[i].Time = now
+		states[i].Seq = s.seq
+		clientStates[states[i].State] = states[i]
+	}
+	s.push(clientID, states)
+	return s.seq
+}
+
+// snapshot returns the most recent value of each state of the given client.
+func (s *stateSyncServer) snapshot(clientID string) []remoteState {
+	latest := make(map[string]remoteState, len(s.states))
+	for k, rs := range s.states {
+		latest[k] = rs
+	}
+	for k, rs := range s.clientStates[clientID] {
+		if current, ok := latest[k]; !ok || rs.Seq > current.Seq {
+			latest[k] = rs
+		}
+	}
+
+	states := make([]remoteState, 0, len(latest))
+	for _, rs := range latest {
+		states = append(states, rs)
+	}
+	return states
+}
+
+func (s *stateSyncServer) latest(clientID, state string) (remoteState, bool) {
+	rs, ok := s.states[state]
+	if crs, cok := s.clientStates[clientID][state]; cok && (!ok || crs.Seq > rs.Seq) {
+		return crs, true
+	}
+	return rs, ok
+}
+
+// clientStatesOf returns the states of the given client and marks it as seen.
+// The states are created when the client has none, after evicting the clients
+// that are expired or exceed the maximum number of clients.
+func (s *stateSyncServer) clientStatesOf(clientID string) map[string]remoteState {
+	now := time.Now()
+
+	states, ok := s.clientStates[clientID]
+	if !ok {
+		s.evict(now)
+		states = make(map[string]remoteState)
+		s.clientStates[clientID] = states
+	}
+	s.lastSeen[clientID] = now
+	return states
+}
+
+// evict removes the states of the clients that are not connected and have not
+// been seen for stateSyncClientTTL. When the maximum number of clients is
+// reached, the states of the least recently seen client that is not connected
+// are removed as well.
+func (s *stateSyncServer) evict(now time.Time) {
+	var oldestID string
+	var oldest time.Time
+
+	for id, seen := range s.lastSeen {
+		if _, ok := s.conns[id]; ok {
+			continue
+		}
+
+		if now.Sub(seen) >= stateSyncClientTTL {
+			s.removeClient(id)
+			continue
+		}
+
+		if oldestID == "" || seen.Before(oldest) {
+			oldestID = id
+			oldest = seen
+		}
+	}
+
+	if len(s.clientStates) >= s.maxClients && oldestID != "" {
+		s.removeClient(oldestID)
+	}
+}
+
+func (s *stateSyncServer) removeClient(clientID string) {
+	delete(s.clientStates, clientID)
+	delete(s.lastSeen, clientID)
+}
+
+// push sends the given states to the connections of the given client, except
+// to the connections of the stores the states come from. Connections that
+// cannot keep up are closed, which makes the client reconnect and
+// resynchronize from a snapshot.
+func (s *stateSyncServer) push(clientID string, states []remoteState) {
+	for conn, storeID := range s.conns[clientID] {
+		pushed := make([]remoteState, 0, len(states))
+		for _, rs := range states {
+			if rs.StoreID != storeID {
+				pushed = append(pushed, rs)
+			}
+		}
+		if len(pushed) == 0 {
+			continue
+		}
+
+		select {
+		case conn <- pushed:
+
+		default:
+			close(conn)
+			s.disconnect(clientID, conn)
+		}
+	}
+}
+
+func (s *stateSyncServer) connect(clientID, storeID string) (chan []remoteState, []remoteState) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	conns, ok := s.conns[clientID]
+	if !ok {
+		conns = make(map[chan []remoteState]string)
+		s.conns[clientID] = conns
+	}
+
+	conn := make(chan []remoteState, stateSyncBufferSize)
+	conns[conn] = storeID
+	return conn, s.snapshot(clientID)
+}
+
+func (s *stateSyncServer) disconnect(clientID string, conn chan []remoteState) {
+	conns := s.conns[clientID]
+	delete(conns, conn)
+	if len(conns) == 0 {
+		delete(s.conns, clientID)
+	}
+
+	if _, ok := s.lastSeen[clientID]; ok {
+		s.lastSeen[clientID] = time.Now()
+	}
+}
+
+func (s *stateSyncServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client")
+	if clientID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if s.authorize == nil || !s.authorize(r, clientID) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.serveEvents(w, r, clientID)
+
+	case http.MethodPost:
+		s.serveReceive(w, r, clientID)
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *stateSyncServer) serveEvents(w http.ResponseWriter, r *http.Request, clientID string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		w.WriteHeader(http.StatusNotImplemented)
+		Log(errors.New("serving synced states failed").
+			WithTag("reason", "response writer is not a flusher"))
+		return
+	}
+
+	conn, snapshot := s.connect(clientID, r.URL.Query().Get("store"))
+	defer func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if _, ok := s.conns[clientID][conn]; ok {
+			s.disconnect(clientID, conn)
+		}
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+
+	for states := snapshot; ; {
+		if err := writeStateSyncEvent(w, states); err != nil {
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+
+		case states, ok = <-conn:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (s *stateSyncServer) serveReceive(w http.ResponseWriter, r *http.Request, clientID string) {
+	var states []remoteState
+	if err := json.NewDecoder(io.LimitReader(r.Body, stateSyncMaxBodySize)).Decode(&states); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		Log(errors.New("decoding synced states failed").
+			WithTag("client", clientID).
+			Wrap(err))
+		return
+	}
+
+	seq := s.receive(clientID, states)
+	w.Header().Set(stateSyncSeqHeader, strconv.FormatUint(seq, 10))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeStateSyncEvent(w io.Writer, states []remoteState) error {
+	b, err := json.Marshal(states)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "data: "+string(b)+"\n\n")
+	return err
+}
+
+// stateSyncClient synchronizes the states set with the Sync option with the
+// server. Server changes are received with an EventSource, which reconnects
+// after network failures. States set on the client are kept pending until the
+// server acknowledges them, and are sent again each time the connection is
+// opened.
+//
+// Server states are authoritative and are ordered by their sequence number.
+// The states pushed while a state is pending are deferred until the server
+// acknowledges it, and are then received only when the server set them after
+// the pending state.
+type stateSyncClient struct {
+	mutex       sync.Mutex
+	url         string
+	source      Value
+	pending     map[string]remoteState
+	deferred    map[string]remoteState
+	seqs        map[string]uint64
+	isConnected bool
+	isClosed    bool
+	release     func()
+	receive     func([]remoteState)
+}
+
+func newStateSyncClient(endpoint, clientID, storeID string, receive func([]remoteState)) *stateSyncClient {
+	c := &stateSyncClient{
+		url: endpoint +
+			"?client=" + url.QueryEscape(clientID) +
+			"&store=" + url.QueryEscape(storeID),
+		pending:  make(map[string]remoteState),
+		deferred: make(map[string]remoteState),
+		seqs:     make(map[string]uint64),
+		release:  func() {},
+		receive:  receive,
+	}
+	c.open()
+	return c
+}
+
+func (c *stateSyncClient) open() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	eventSource := Window().Get("EventSource")
+	if c.isClosed || !eventSource.Truthy() {
+		return
+	}
+	source := eventSource.New(c.url)
+	c.source = source
+
+	onOpen := FuncOf(func(this Value, args []Value) any {
+		c.onOpen()
+		return nil
+	})
+
+	onMessage := FuncOf(func(this Value, args []Value) any {
+		c.onMessage(args[0].Get("data").String())
+		return nil
+	})
+
+	onError := FuncOf(func(this Value, args []Value) any {
+		c.onError(source.Get("readyState").Int() == eventSourceClosed)
+		return nil
+	})
+
+	c.release = func() {
+		onOpen.Release()
+		onMessage.Release()
+		onError.Release()
+	}
+
+	source.Set("onopen", onOpen)
+	source.Set("onmessage", onMessage)
+	source.Set("onerror", onError)
+}
+
+// onOpen sends the pending states. Sequence numbers are reset because the
+// connection starts with a snapshot of the server states, which could have
+// been restarted.
+func (c *stateSyncClient) onOpen() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.isConnected = true
+	c.seqs = make(map[string]uint64)
+	c.flush()
+}
+
+func (c *stateSyncClient) onMessage(data string) {
+	var states []remoteState
+	if err := json.Unmarshal([]byte(data), &states); err != nil {
+		Log(errors.New("decoding synced states failed").Wrap(err))
+		return
+	}
+
+	c.mutex.Lock()
+	received := make([]remoteState, 0, len(states))
+	for _, rs := range states {
+		if _, ok := c.pending[rs.State]; ok {
+			if d, ok := c.deferred[rs.State]; !ok || rs.Seq > d.Seq {
+				c.deferred[rs.State] = rs
+			}
+			continue
+		}
+
+		if seq, ok := c.seqs[rs.State]; ok && rs.Seq <= seq {
+			continue
+		}
+		c.seqs[rs.State] = rs.Seq
+		received = append(received, rs)
+	}
+	c.mutex.Unlock()
+
+	if len(received) != 0 {
+		c.receive(received)
+	}
+}
+
+// onError handles a connection failure. The EventSource reconnects by itself
+// unless it has been closed, which happens when the server responds with an
+// error.
+func (c *stateSyncClient) onError(isClosed bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.isConnected = false
+	if !isClosed || c.isClosed {
+		return
+	}
+
+	c.source.Call("close")
+	c.release()
+	c.release = func() {}
+	time.AfterFunc(stateSyncReconnectDelay, c.open)
+}
+
+// push sends the given state to the server. The state is sent when the
+// connection is restored if the client is offline.
+func (c *stateSyncClient) push(rs remoteState) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.pending[rs.State] = rs
+	if c.isConnected {
+		c.flush()
+	}
+}
+
+func (c *stateSyncClient) flush() {
+	if len(c.pending) == 0 {
+		return
+	}
+
+	states := make([]remoteState, 0, len(c.pending))
+	for _, rs := range c.pending {
+		states = append(states, rs)
+	}
+
+	b, err := json.Marshal(states)
+	if err != nil {
+		Log(errors.New("encoding synced states failed").Wrap(err))
+		return
+	}
+
+	var onResponse, onFailure Func
+	release := func() {
+		onResponse.Release()
+		onFailure.Release()
+	}
+
+	onResponse = FuncOf(func(this Value, args []Value) any {
+		release()
+		if !args[0].Get("ok").Bool() {
+			Log(errors.New("sending synced states failed").
+				WithTag("status", args[0].Get("status").Int()))
+			return nil
+		}
+
+		seq, _ := strconv.ParseUint(args[0].
+			Get("headers").
+			Call("get", stateSyncSeqHeader).
+			String(), 10, 64)
+		c.acknowledge(states, seq)
+		return nil
+	})
+
+	onFailure = FuncOf(func(this Value, args []Value) any {
+		release()
+		Log(errors.New("sending synced states failed").
+			WithTag("error", args[0].Get("message").String()))
+		return nil
+	})
+
+	Window().
+		Call("fetch", c.url, map[string]any{
+			"method":  http.MethodPost,
+			"headers": map[string]any{"Content-Type": "application/json"},
+			"body":    string(b),
+		}).
+		Call("then", onResponse, onFailure)
+}
+
+// acknowledge removes the given states from the pending states, unless they
+// have been set again since they were sent. The given sequence number is the
+// one the server assigned to the states. Deferred states that the server set
+// after them are received.
+func (c *stateSyncClient) acknowledge(states []remoteState, seq uint64) {
+	c.mutex.Lock()
+	received := make([]remoteState, 0, len(c.deferred))
+	for _, rs := range states {
+		if seq > c.seqs[rs.State] {
+			c.seqs[rs.State] = seq
+		}
+
+		p, ok := c.pending[rs.State]
+		if !ok || !p.Time.Equal(rs.Time) || p.StoreID != rs.StoreID {
+			continue
+		}
+		delete(c.pending, rs.State)
+
+		if d, ok := c.deferred[rs.State]; ok {
+			delete(c.deferred, rs.State)
+			if d.Seq > c.seqs[rs.State] {
+				c.seqs[rs.State] = d.Seq
+				received = append(received, d)
+			}
+		}
+	}
+	c.mutex.Unlock()
+
+	if len(received) != 0 {
+		c.receive(received)
+	}
+}
+
+func (c *stateSyncClient) close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.isClosed = true
+	c.isConnected = false
+	if c.source != nil {
+		c.source.Call("close")
+	}
+	c.release()
+}
+
+func (s *store) initSync(endpoint, clientID string) {
+	s.sync = newStateSyncClient(endpoint, clientID, s.id, s.receiveSyncedStates)
+}
+
+// receiveSyncedStates updates the store with the states pushed by the server.
+// Server states are authoritative: the states that local writes supersede are
+// already discarded by the state sync client.
+func (s *store) receiveSyncedStates(states []remoteState) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	for _, rs := range states {
+		if _, ok := s.derived[rs.State]; ok {
+			continue
+		}
+		s.applyRemoteState(rs, now, Sync)
+	}
+}
+
+func (s *store) syncState(key string, state State) error {
+	if s.sync == nil {
+		return nil
+	}
+
+	rs, err := newRemoteState(key, state)
+	if err != nil {
+		return err
+	}
+	s.sync.push(rs)
+	return nil
+}
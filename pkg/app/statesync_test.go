@@ -0,0 +1,365 @@
+//go:build !wasm
+// +build !wasm
+
+package app
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerSyncStates(t *testing.T) {
+	h := &Handler{
+		Resources:  LocalDir(""),
+		SyncStates: true,
+		AuthorizeStateSync: func(r *http.Request, clientID string) bool {
+			c, err := r.Cookie("token")
+			return err == nil && c.Value == "token-"+clientID
+		},
+	}
+	s := httptest.NewServer(h)
+	defer s.Close()
+
+	endpoint := s.URL + "/app-states"
+	key := "/test/sync"
+
+	t.Run("endpoint url is passed to the client", func(t *testing.T) {
+		h.once.Do(h.init)
+		require.Equal(t, "/app-states", h.Env["GOAPP_STATE_SYNC_URL"])
+	})
+
+	t.Run("request without client id is rejected", func(t *testing.T) {
+		res, err := http.Get(endpoint)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("request without authorization is rejected", func(t *testing.T) {
+		res, err := http.Get(endpoint + "?client=a")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("request authorized for another client is rejected", func(t *testing.T) {
+		req := testSyncedStatesRequest(t, http.MethodGet, endpoint, "a", nil)
+		req.URL.RawQuery = "client=b"
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+
+	t.Run("unsupported method is rejected", func(t *testing.T) {
+		req := testSyncedStatesRequest(t, http.MethodPut, endpoint, "a", nil)
+
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+	})
+
+	t.Run("client states are received", func(t *testing.T) {
+		testPostSyncedStates(t, endpoint, "a", remoteState{
+			State:   key,
+			Value:   json.RawMessage("42"),
+			Time:    time.Now(),
+			StoreID: "a",
+		})
+
+		var v int
+		require.NoError(t, h.GetClientState("a", key, &v))
+		require.Equal(t, 42, v)
+
+		v = 0
+		require.NoError(t, h.GetClientState("b", key, &v))
+		require.Zero(t, v)
+	})
+
+	t.Run("client states are ordered by the server", func(t *testing.T) {
+		testPostSyncedStates(t, endpoint, "a", remoteState{
+			State:   key + "/future",
+			Value:   json.RawMessage("21"),
+			Time:    time.Now().Add(time.Hour),
+			StoreID: "a",
+		})
+		require.NoError(t, h.SetClientState("a", key+"/future", 42))
+
+		var v int
+		require.NoError(t, h.GetClientState("a", key+"/future", &v))
+		require.Equal(t, 42, v)
+
+		testPostSyncedStates(t, endpoint, "a", remoteState{
+			State:   key + "/future",
+			Value:   json.RawMessage("84"),
+			Time:    time.Now().Add(-time.Hour),
+			StoreID: "a",
+		})
+		require.NoError(t, h.GetClientState("a", key+"/future", &v))
+		require.Equal(t, 84, v)
+	})
+
+	t.Run("connection starts with a snapshot", func(t *testing.T) {
+		events, close := testSyncedStatesEvents(t, endpoint, "a", "")
+		defer close()
+
+		states := <-events
+		require.Len(t, states, 2)
+
+		values := make(map[string]json.RawMessage)
+		for _, rs := range states {
+			values[rs.State] = rs.Value
+		}
+		require.Equal(t, json.RawMessage("42"), values[key])
+	})
+
+	t.Run("server states are pushed", func(t *testing.T) {
+		eventsA, closeA := testSyncedStatesEvents(t, endpoint, "a", "")
+		defer closeA()
+		<-eventsA
+
+		eventsB, closeB := testSyncedStatesEvents(t, endpoint, "b", "")
+		defer closeB()
+		<-eventsB
+
+		err := h.SetClientState("b", key, 84)
+		require.NoError(t, err)
+		states := <-eventsB
+		require.Len(t, states, 1)
+		require.Equal(t, json.RawMessage("84"), states[0].Value)
+		require.Equal(t, stateSyncServerID, states[0].StoreID)
+
+		err = h.SetState(key+"/all", "hello")
+		require.NoError(t, err)
+		require.Equal(t, key+"/all", (<-eventsA)[0].State)
+		require.Equal(t, key+"/all", (<-eventsB)[0].State)
+
+		var v int
+		require.NoError(t, h.GetClientState("a", key, &v))
+		require.Equal(t, 42, v)
+		require.NoError(t, h.GetClientState("b", key, &v))
+		require.Equal(t, 84, v)
+	})
+
+	t.Run("client states are pushed to the other client connections", func(t *testing.T) {
+		events, close := testSyncedStatesEvents(t, endpoint, "a", "store-b")
+		defer close()
+		<-events
+
+		senderEvents, closeSender := testSyncedStatesEvents(t, endpoint, "a", "store-a")
+		defer closeSender()
+		<-senderEvents
+
+		testPostSyncedStates(t, endpoint, "a", remoteState{
+			State:   key,
+			Value:   json.RawMessage("168"),
+			Time:    time.Now(),
+			StoreID: "store-a",
+		})
+		states := <-events
+		require.Len(t, states, 1)
+		require.Equal(t, json.RawMessage("168"), states[0].Value)
+		require.NotZero(t, states[0].Seq)
+
+		require.NoError(t, h.SetClientState("a", key+"/server", 1))
+		states = <-senderEvents
+		require.Len(t, states, 1)
+		require.Equal(t, key+"/server", states[0].State)
+		<-events
+	})
+
+	t.Run("setting a state that cannot be encoded returns an error", func(t *testing.T) {
+		err := h.SetState(key, func() {})
+		require.Error(t, err)
+	})
+}
+
+func TestHandlerSyncStatesDisabled(t *testing.T) {
+	h := &Handler{Resources: LocalDir("")}
+
+	require.Error(t, h.SetState("/test/sync", 42))
+	require.Error(t, h.SetClientState("a", "/test/sync", 42))
+
+	var v int
+	require.Error(t, h.GetClientState("a", "/test/sync", &v))
+}
+
+func TestStateSyncServerEviction(t *testing.T) {
+	t.Run("expired client is removed", func(t *testing.T) {
+		s := newStateSyncServer(nil)
+		s.set("a", "/test", 42)
+		s.lastSeen["a"] = time.Now().Add(-stateSyncClientTTL)
+
+		s.set("b", "/test", 42)
+		require.NotContains(t, s.clientStates, "a")
+		require.Contains(t, s.clientStates, "b")
+	})
+
+	t.Run("least recently seen client is removed when there are too many clients", func(t *testing.T) {
+		s := newStateSyncServer(nil)
+		s.maxClients = 2
+		s.set("a", "/test", 42)
+		s.set("b", "/test", 42)
+		s.lastSeen["b"] = time.Now().Add(-time.Minute)
+
+		s.set("c", "/test", 42)
+		require.Len(t, s.clientStates, 2)
+		require.Contains(t, s.clientStates, "a")
+		require.NotContains(t, s.clientStates, "b")
+		require.Contains(t, s.clientStates, "c")
+	})
+
+	t.Run("connected client is not removed", func(t *testing.T) {
+		s := newStateSyncServer(nil)
+		s.maxClients = 1
+		s.set("a", "/test", 42)
+		s.lastSeen["a"] = time.Now().Add(-stateSyncClientTTL)
+		s.connect("a", "")
+
+		s.set("b", "/test", 42)
+		require.Contains(t, s.clientStates, "a")
+		require.Contains(t, s.clientStates, "b")
+	})
+}
+
+func TestStoreSync(t *testing.T) {
+	key := "/test/store/sync"
+
+	bar := &bar{}
+	d := NewClientTester(bar)
+	defer d.Close()
+	s := newStore(d)
+	defer s.Close()
+	s.initSync("/app-states", "client")
+
+	t.Run("synced state is pending until acknowledged", func(t *testing.T) {
+		s.Set(key, 42, Sync)
+		require.Contains(t, s.sync.pending, key)
+
+		rs := s.sync.pending[key]
+		require.Equal(t, json.RawMessage("42"), rs.Value)
+
+		s.Set(key, 43, Sync)
+		s.sync.acknowledge([]remoteState{rs}, 1)
+		require.Contains(t, s.sync.pending, key)
+
+		s.sync.acknowledge([]remoteState{s.sync.pending[key]}, 2)
+		require.NotContains(t, s.sync.pending, key)
+	})
+
+	t.Run("not synced state is not sent", func(t *testing.T) {
+		s.Set(key+"/local", 42)
+		require.NotContains(t, s.sync.pending, key+"/local")
+	})
+
+	t.Run("pushed state updates observers", func(t *testing.T) {
+		var observed int
+		s.Observe(key+"/pushed", bar).Value(&observed)
+
+		s.sync.onMessage(`[{"State":"/test/store/sync/pushed","Value":21,"Time":"2020-01-01T00:00:00Z","StoreID":"server","Seq":10}]`)
+		d.Consume()
+		require.Equal(t, 21, observed)
+		require.True(t, s.states[key+"/pushed"].IsSynced)
+	})
+
+	t.Run("pushed state is ordered by sequence number", func(t *testing.T) {
+		s.sync.onMessage(`[{"State":"/test/store/sync/pushed","Value":84,"Time":"2030-01-01T00:00:00Z","StoreID":"server","Seq":9}]`)
+
+		var v int
+		s.Get(key+"/pushed", &v)
+		require.Equal(t, 21, v)
+
+		s.Set(key+"/pushed", 42)
+		s.sync.acknowledge([]remoteState{s.sync.pending[key+"/pushed"]}, 11)
+		s.sync.onMessage(`[{"State":"/test/store/sync/pushed","Value":84,"Time":"2020-01-01T00:00:00Z","StoreID":"server","Seq":12}]`)
+		s.Get(key+"/pushed", &v)
+		require.Equal(t, 84, v)
+	})
+
+	t.Run("pushed state is deferred while local state is pending", func(t *testing.T) {
+		s.Set(key+"/pending", 1, Sync)
+		s.Set(key+"/pending/older", 1, Sync)
+		pending := s.sync.pending[key+"/pending"]
+		pendingOlder := s.sync.pending[key+"/pending/older"]
+
+		s.sync.onMessage(`[` +
+			`{"State":"/test/store/sync/pending","Value":2,"StoreID":"server","Seq":21},` +
+			`{"State":"/test/store/sync/pending/older","Value":2,"StoreID":"server","Seq":19}` +
+			`]`)
+
+		var v int
+		s.Get(key+"/pending", &v)
+		require.Equal(t, 1, v)
+		s.Get(key+"/pending/older", &v)
+		require.Equal(t, 1, v)
+
+		s.sync.acknowledge([]remoteState{pending, pendingOlder}, 20)
+		s.Get(key+"/pending", &v)
+		require.Equal(t, 2, v)
+		s.Get(key+"/pending/older", &v)
+		require.Equal(t, 1, v)
+		require.Empty(t, s.sync.deferred)
+	})
+}
+
+func testSyncedStatesRequest(t *testing.T, method, endpoint, clientID string, body io.Reader) *http.Request {
+	req, err := http.NewRequest(method, endpoint+"?client="+clientID, body)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: "token", Value: "token-" + clientID})
+	return req
+}
+
+func testPostSyncedStates(t *testing.T, endpoint, clientID string, states ...remoteState) {
+	b, err := json.Marshal(states)
+	require.NoError(t, err)
+
+	req := testSyncedStatesRequest(t, http.MethodPost, endpoint, clientID, strings.NewReader(string(b)))
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+	require.NotEmpty(t, res.Header.Get(stateSyncSeqHeader))
+}
+
+func testSyncedStatesEvents(t *testing.T, endpoint, clientID, storeID string) (<-chan []remoteState, func()) {
+	req := testSyncedStatesRequest(t, http.MethodGet, endpoint, clientID, nil)
+	req.URL.RawQuery += "&store=" + storeID
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	events := make(chan []remoteState, stateSyncBufferSize)
+	go func() {
+		defer close(events)
+
+		scanner := bufio.NewScanner(res.Body)
+		for scanner.Scan() {
+			data := strings.TrimPrefix(scanner.Text(), "data: ")
+			if data == scanner.Text() {
+				continue
+			}
+
+			var states []remoteState
+			if err := json.Unmarshal([]byte(data), &states); err != nil {
+				return
+			}
+			events <- states
+		}
+	}()
+
+	return events, func() { res.Body.Close() }
+}
@@ -30,6 +30,48 @@ type BrowserStorage interface {
 	Clear()
 }
 
+// StateStorage is the interface that describes a storage backend where states
+// set with the Persist option are saved.
+type StateStorage interface {
+	// Set sets the value to the given key. The value must be json convertible.
+	Set(k string, v any) error
+
+	// Get gets the item associated to the given key and store it in the given
+	// value. The value is unchanged when there is no item associated to the
+	// key.
+	// It returns an error if v is not a pointer.
+	Get(k string, v any) error
+
+	// Del deletes the item associated with the given key.
+	Del(k string)
+}
+
+// keyLister is the interface that describes a storage that can list the keys
+// of its items.
+type keyLister interface {
+	keys() []string
+}
+
+// asyncLoader is the interface that describes a storage that loads its items
+// asynchronously. Items are not found until they are loaded.
+type asyncLoader interface {
+	// onLoad sets the function that is called with the keys of the items once
+	// they are loaded.
+	onLoad(func(keys []string))
+}
+
+// stateStorage is the storage backend set with UseStateStorage.
+var stateStorage StateStorage
+
+// UseStateStorage sets the storage backend where persistent states are saved
+// when the app runs in a web browser. Persistent states are saved in local
+// storage when no state storage is set.
+//
+// It must be called before the app is started.
+func UseStateStorage(s StateStorage) {
+	stateStorage = s
+}
+
 type memoryStorage struct {
 	mu   sync.RWMutex
 	data map[string][]byte
@@ -103,6 +145,17 @@ func (s *memoryStorage) Key(i int) (string, error) {
 		WithTag("len", s.Len())
 }
 
+func (s *memoryStorage) keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
 type jsStorage struct {
 	name  string
 	mutex sync.RWMutex
@@ -181,3 +234,17 @@ func (s *jsStorage) Key(i int) (string, error) {
 
 	return Window().Get(s.name).Call("key", i).String(), nil
 }
+
+func (s *jsStorage) keys() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	storage := Window().Get(s.name)
+	l := storage.Get("length").Int()
+
+	keys := make([]string, 0, l)
+	for i := 0; i < l; i++ {
+		keys = append(keys, storage.Call("key", i).String())
+	}
+	return keys
+}
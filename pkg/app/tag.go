@@ -43,3 +43,17 @@ func T(name string, value any) Tag {
 		Value: toString(value),
 	}
 }
+
+func mergeTags(tags ...Tagger) Tags {
+	var merged Tags
+	for _, t := range tags {
+		if merged == nil {
+			merged = t.Tags()
+			continue
+		}
+		for k, v := range t.Tags() {
+			merged[k] = v
+		}
+	}
+	return merged
+}
@@ -137,6 +137,9 @@ func TestMatch(tree UI, d TestUIDescriptor) error {
 	case RawHTML:
 		return matchRaw(tree, d)
 
+	case FragmentElem:
+		return matchFragment(tree, d)
+
 	default:
 		return errors.New("the UI element is not matching the descriptor").
 			WithTag("reason", "unavailable matching for the kind").
@@ -144,6 +147,30 @@ func TestMatch(tree UI, d TestUIDescriptor) error {
 	}
 }
 
+func matchFragment(n UI, d TestUIDescriptor) error {
+	aChildren := n.getChildren()
+	bChildren := d.Expected.getChildren()
+
+	if len(aChildren) != len(bChildren) {
+		return errors.New("the fragment is not matching the descriptor").
+			WithTag("name", n.name()).
+			WithTag("reason", "unexpected children length").
+			WithTag("expected-children-length", len(bChildren)).
+			WithTag("current-children-length", len(aChildren))
+	}
+
+	for i, b := range bChildren {
+		if err := TestMatch(aChildren[i], TestUIDescriptor{Expected: b}); err != nil {
+			return errors.New("the fragment is not matching the descriptor").
+				WithTag("name", n.name()).
+				WithTag("reason", "unexpected child").
+				WithTag("index", i).
+				Wrap(err)
+		}
+	}
+	return nil
+}
+
 func matchText(n UI, d TestUIDescriptor) error {
 	a := n.(*text)
 	b := d.Expected.(*text)
@@ -36,6 +36,10 @@ func (t *text) name() string {
 	return "text"
 }
 
+func (t *text) getKey() string {
+	return ""
+}
+
 func (t *text) self() UI {
 	return t
 }
@@ -85,6 +89,19 @@ func (t *text) mount(d Dispatcher) error {
 	return nil
 }
 
+func (t *text) hydrate(d Dispatcher, cursor *domCursor) error {
+	if !cursor.isText() {
+		return remount(d, t, cursor)
+	}
+
+	t.disp = d
+	t.jsvalue = cursor.next()
+	if t.jsvalue.nodeValue() != t.value {
+		t.jsvalue.setNodeValue(t.value)
+	}
+	return nil
+}
+
 func (t *text) dismount() {
 	t.jsvalue = nil
 }